@@ -0,0 +1,147 @@
+package calc
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+func ParseIPv4(ipAddress string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ipAddress))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("ParseIPv4: %w", ErrInvalidAddress)
+	}
+
+	if !addr.Unmap().Is4() {
+		return netip.Addr{}, fmt.Errorf("ParseIPv4: %w", ErrNotIPv4)
+	}
+
+	return addr.Unmap(), nil
+}
+
+func ParseIPv6(ipAddress string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ipAddress))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("ParseIPv6: %w", ErrInvalidAddress)
+	}
+
+	if !addr.Is6() {
+		return netip.Addr{}, fmt.Errorf("ParseIPv6: %w", ErrNotIPv6)
+	}
+
+	return addr, nil
+}
+
+func IPv4ToHexFormat(ipv4 netip.Addr) (string, error) {
+	if !ipv4.Is4() {
+		return "", fmt.Errorf("IPv4ToHexFormat: %w", ErrNotIPv4)
+	}
+
+	octets := ipv4.As4()
+
+	return fmt.Sprintf("%02X%02X%02X%02X", octets[0], octets[1], octets[2], octets[3]), nil
+}
+
+func IPv4ToBinFormat(ipv4 netip.Addr) (string, error) {
+	if !ipv4.Is4() {
+		return "", fmt.Errorf("IPv4ToBinFormat: %w", ErrNotIPv4)
+	}
+
+	octets := ipv4.As4()
+
+	return FormatBinInNimbles(fmt.Sprintf("%08b%08b%08b%08b", octets[0], octets[1], octets[2], octets[3])), nil
+}
+
+func HexToIPv4Format(hexNumber string) (netip.Addr, error) {
+	octets, err := parseAddrDigits(hexNumber, 4, 16)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("HexToIPv4Format: %w", ErrInvalidHex)
+	}
+
+	return netip.AddrFrom4(*(*[4]byte)(octets)), nil
+}
+
+func BinToIPv4Format(binNumber string) (netip.Addr, error) {
+	octets, err := parseAddrDigits(binNumber, 4, 2)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("BinToIPv4Format: %w", ErrInvalidBin)
+	}
+
+	return netip.AddrFrom4(*(*[4]byte)(octets)), nil
+}
+
+func IPv6ToHexFormat(ipv6 netip.Addr) (string, error) {
+	if !ipv6.Is6() {
+		return "", fmt.Errorf("IPv6ToHexFormat: %w", ErrNotIPv6)
+	}
+
+	octets := ipv6.As16()
+	hextets := make([]string, 0, 8)
+
+	for i := 0; i < 16; i += 2 {
+		hextets = append(hextets, fmt.Sprintf("%02X%02X", octets[i], octets[i+1]))
+	}
+
+	return strings.Join(hextets, ":"), nil
+}
+
+func IPv6ToBinFormat(ipv6 netip.Addr) (string, error) {
+	if !ipv6.Is6() {
+		return "", fmt.Errorf("IPv6ToBinFormat: %w", ErrNotIPv6)
+	}
+
+	octets := ipv6.As16()
+	hextets := make([]string, 0, 8)
+
+	for i := 0; i < 16; i += 2 {
+		hextets = append(hextets, FormatBinInNimbles(fmt.Sprintf("%08b%08b", octets[i], octets[i+1])))
+	}
+
+	return strings.Join(hextets, " : "), nil
+}
+
+func HexToIPv6Format(hexNumber string) (netip.Addr, error) {
+	octets, err := parseAddrDigits(hexNumber, 16, 16)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("HexToIPv6Format: %w", ErrInvalidHex)
+	}
+
+	return netip.AddrFrom16(*(*[16]byte)(octets)), nil
+}
+
+func BinToIPv6Format(binNumber string) (netip.Addr, error) {
+	octets, err := parseAddrDigits(binNumber, 16, 2)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("BinToIPv6Format: %w", ErrInvalidBin)
+	}
+
+	return netip.AddrFrom16(*(*[16]byte)(octets)), nil
+}
+
+// parseAddrDigits parses size octets written in base 2 or 16, ignoring the
+// spaces and colons used to group the digits.
+func parseAddrDigits(number string, size int, base int) ([]byte, error) {
+	digitsPerOctet := 2
+	if base == 2 {
+		digitsPerOctet = 8
+	}
+
+	trimmedNumber := strings.NewReplacer(" ", "", ":", "").Replace(number)
+	if len(trimmedNumber) != size*digitsPerOctet {
+		return nil, ErrInvalidAddress
+	}
+
+	octets := make([]byte, size)
+
+	for i := range octets {
+		value, err := strconv.ParseUint(trimmedNumber[i*digitsPerOctet:(i+1)*digitsPerOctet], base, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		octets[i] = byte(value)
+	}
+
+	return octets, nil
+}
@@ -0,0 +1,336 @@
+package calc
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+	"testing/quick"
+)
+
+func TestParseIPv4(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		{"192.168.1.1", "192.168.1.1", nil},
+		{" 10.0.0.1 ", "10.0.0.1", nil},
+		{"::ffff:10.0.0.1", "10.0.0.1", nil},
+		{"256.0.0.1", "", ErrInvalidAddress},
+		{"", "", ErrInvalidAddress},
+		{"2001:db8::1", "", ErrNotIPv4},
+	}
+
+	for _, test := range tests {
+		got, err := ParseIPv4(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseIPv4(%q) error = %v, want %v", test.input, err, test.err)
+			continue
+		}
+
+		if test.err == nil && got.String() != test.want {
+			t.Errorf("ParseIPv4(%q) = %s, want %s", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseIPv6(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		{"2001:db8::1", "2001:db8::1", nil},
+		{"::", "::", nil},
+		{"::ffff:10.0.0.1", "::ffff:10.0.0.1", nil},
+		{"10.0.0.1", "", ErrNotIPv6},
+		{"2001:db8:::1", "", ErrInvalidAddress},
+	}
+
+	for _, test := range tests {
+		got, err := ParseIPv6(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseIPv6(%q) error = %v, want %v", test.input, err, test.err)
+			continue
+		}
+
+		if test.err == nil && got.String() != test.want {
+			t.Errorf("ParseIPv6(%q) = %s, want %s", test.input, got, test.want)
+		}
+	}
+}
+
+func TestIPv4ToHexAndBinFormat(t *testing.T) {
+	tests := []struct {
+		input string
+		hex   string
+		bin   string
+	}{
+		{"0.0.0.0", "00000000", "0000 0000 0000 0000 0000 0000 0000 0000"},
+		{"192.168.1.1", "C0A80101", "1100 0000 1010 1000 0000 0001 0000 0001"},
+		{"255.255.255.255", "FFFFFFFF", "1111 1111 1111 1111 1111 1111 1111 1111"},
+	}
+
+	for _, test := range tests {
+		ipv4 := netip.MustParseAddr(test.input)
+
+		if got, err := IPv4ToHexFormat(ipv4); err != nil || got != test.hex {
+			t.Errorf("IPv4ToHexFormat(%s) = %q, %v, want %q", test.input, got, err, test.hex)
+		}
+
+		if got, err := IPv4ToBinFormat(ipv4); err != nil || got != test.bin {
+			t.Errorf("IPv4ToBinFormat(%s) = %q, %v, want %q", test.input, got, err, test.bin)
+		}
+	}
+
+	ipv6 := netip.MustParseAddr("2001:db8::1")
+
+	if _, err := IPv4ToHexFormat(ipv6); !errors.Is(err, ErrNotIPv4) {
+		t.Errorf("IPv4ToHexFormat(%s) error = %v, want %v", ipv6, err, ErrNotIPv4)
+	}
+
+	if _, err := IPv4ToBinFormat(ipv6); !errors.Is(err, ErrNotIPv4) {
+		t.Errorf("IPv4ToBinFormat(%s) error = %v, want %v", ipv6, err, ErrNotIPv4)
+	}
+}
+
+func TestHexToIPv4Format(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		{"C0A80101", "192.168.1.1", nil},
+		{"c0 a8 01 01", "192.168.1.1", nil},
+		{"C0:A8:01:01", "192.168.1.1", nil},
+		{"C0A801", "", ErrInvalidHex},
+		{"C0A8010G", "", ErrInvalidHex},
+		{"", "", ErrInvalidHex},
+	}
+
+	for _, test := range tests {
+		got, err := HexToIPv4Format(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("HexToIPv4Format(%q) error = %v, want %v", test.input, err, test.err)
+			continue
+		}
+
+		if test.err == nil && got.String() != test.want {
+			t.Errorf("HexToIPv4Format(%q) = %s, want %s", test.input, got, test.want)
+		}
+	}
+}
+
+func TestBinToIPv4Format(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		{"11000000101010000000000100000001", "192.168.1.1", nil},
+		{"1100 0000 1010 1000 0000 0001 0000 0001", "192.168.1.1", nil},
+		{"1100 0000 1010 1000 0000 0001 0000 000", "", ErrInvalidBin},
+		{"1100 0000 1010 1000 0000 0001 0000 0002", "", ErrInvalidBin},
+	}
+
+	for _, test := range tests {
+		got, err := BinToIPv4Format(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("BinToIPv4Format(%q) error = %v, want %v", test.input, err, test.err)
+			continue
+		}
+
+		if test.err == nil && got.String() != test.want {
+			t.Errorf("BinToIPv4Format(%q) = %s, want %s", test.input, got, test.want)
+		}
+	}
+}
+
+func TestIPv6Formats(t *testing.T) {
+	ipv6 := netip.MustParseAddr("2001:db8::8a2e:370:7334")
+	wantHex := "2001:0DB8:0000:0000:0000:8A2E:0370:7334"
+
+	gotHex, err := IPv6ToHexFormat(ipv6)
+	if err != nil || gotHex != wantHex {
+		t.Errorf("IPv6ToHexFormat(%s) = %q, %v, want %q", ipv6, gotHex, err, wantHex)
+	}
+
+	gotBin, err := IPv6ToBinFormat(ipv6)
+	if err != nil || len(gotBin) != 128+8*3+7*3 {
+		t.Errorf("IPv6ToBinFormat(%s) = %q, %v", ipv6, gotBin, err)
+	}
+
+	if got, err := HexToIPv6Format(wantHex); err != nil || got != ipv6 {
+		t.Errorf("HexToIPv6Format(%q) = %s, %v, want %s", wantHex, got, err, ipv6)
+	}
+
+	if got, err := BinToIPv6Format(gotBin); err != nil || got != ipv6 {
+		t.Errorf("BinToIPv6Format(%q) = %s, %v, want %s", gotBin, got, err, ipv6)
+	}
+
+	if _, err := IPv6ToHexFormat(netip.MustParseAddr("10.0.0.1")); !errors.Is(err, ErrNotIPv6) {
+		t.Errorf("IPv6ToHexFormat(10.0.0.1) error = %v, want %v", err, ErrNotIPv6)
+	}
+
+	if _, err := IPv6ToBinFormat(netip.MustParseAddr("10.0.0.1")); !errors.Is(err, ErrNotIPv6) {
+		t.Errorf("IPv6ToBinFormat(10.0.0.1) error = %v, want %v", err, ErrNotIPv6)
+	}
+
+	if _, err := HexToIPv6Format("2001:0DB8"); !errors.Is(err, ErrInvalidHex) {
+		t.Errorf("HexToIPv6Format(2001:0DB8) error = %v, want %v", err, ErrInvalidHex)
+	}
+
+	if _, err := BinToIPv6Format("0101"); !errors.Is(err, ErrInvalidBin) {
+		t.Errorf("BinToIPv6Format(0101) error = %v, want %v", err, ErrInvalidBin)
+	}
+}
+
+func TestIPv4RoundTrip(t *testing.T) {
+	roundTrip := func(octets [4]byte) bool {
+		ipv4 := netip.AddrFrom4(octets)
+
+		hexValue, err := IPv4ToHexFormat(ipv4)
+		if err != nil {
+			return false
+		}
+
+		fromHex, err := HexToIPv4Format(hexValue)
+		if err != nil || fromHex != ipv4 {
+			return false
+		}
+
+		binValue, err := IPv4ToBinFormat(ipv4)
+		if err != nil {
+			return false
+		}
+
+		fromBin, err := BinToIPv4Format(binValue)
+
+		return err == nil && fromBin == ipv4
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIPv6RoundTrip(t *testing.T) {
+	roundTrip := func(octets [16]byte) bool {
+		ipv6 := netip.AddrFrom16(octets)
+
+		hexValue, err := IPv6ToHexFormat(ipv6)
+		if err != nil {
+			return false
+		}
+
+		fromHex, err := HexToIPv6Format(hexValue)
+		if err != nil || fromHex != ipv6 {
+			return false
+		}
+
+		binValue, err := IPv6ToBinFormat(ipv6)
+		if err != nil {
+			return false
+		}
+
+		fromBin, err := BinToIPv6Format(binValue)
+
+		return err == nil && fromBin == ipv6
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzParseIPv4(f *testing.F) {
+	f.Add("192.168.1.1")
+	f.Add("::ffff:1.2.3.4")
+	f.Add("1.2.3")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ipv4, err := ParseIPv4(input)
+		if err == nil && !ipv4.Is4() {
+			t.Errorf("ParseIPv4(%q) = %s, which is not IPv4", input, ipv4)
+		}
+	})
+}
+
+func FuzzParseIPv6(f *testing.F) {
+	f.Add("2001:db8::1")
+	f.Add("::")
+	f.Add("1.2.3.4")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ipv6, err := ParseIPv6(input)
+		if err == nil && !ipv6.Is6() {
+			t.Errorf("ParseIPv6(%q) = %s, which is not IPv6", input, ipv6)
+		}
+	})
+}
+
+func FuzzHexToIPv4Format(f *testing.F) {
+	f.Add("C0A80101")
+	f.Add("c0 a8 01 01")
+	f.Add("zz")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ipv4, err := HexToIPv4Format(input)
+		if err != nil {
+			return
+		}
+
+		hexValue, err := IPv4ToHexFormat(ipv4)
+		if err != nil {
+			t.Fatalf("IPv4ToHexFormat(%s) error = %v", ipv4, err)
+		}
+
+		if again, err := HexToIPv4Format(hexValue); err != nil || again != ipv4 {
+			t.Errorf("HexToIPv4Format(%q) = %s, %v, want %s", hexValue, again, err, ipv4)
+		}
+	})
+}
+
+func FuzzBinToIPv4Format(f *testing.F) {
+	f.Add("1100 0000 1010 1000 0000 0001 0000 0001")
+	f.Add("1")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ipv4, err := BinToIPv4Format(input)
+		if err != nil {
+			return
+		}
+
+		binValue, err := IPv4ToBinFormat(ipv4)
+		if err != nil {
+			t.Fatalf("IPv4ToBinFormat(%s) error = %v", ipv4, err)
+		}
+
+		if again, err := BinToIPv4Format(binValue); err != nil || again != ipv4 {
+			t.Errorf("BinToIPv4Format(%q) = %s, %v, want %s", binValue, again, err, ipv4)
+		}
+	})
+}
+
+func FuzzHexToIPv6Format(f *testing.F) {
+	f.Add("2001:0DB8:0000:0000:0000:0000:0000:0001")
+	f.Add("20010db8")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ipv6, err := HexToIPv6Format(input)
+		if err == nil && !ipv6.Is6() {
+			t.Errorf("HexToIPv6Format(%q) = %s, which is not IPv6", input, ipv6)
+		}
+	})
+}
+
+func FuzzBinToIPv6Format(f *testing.F) {
+	f.Add("0010 0000 0000 0001 : 0000 1101 1011 1000")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ipv6, err := BinToIPv6Format(input)
+		if err == nil && !ipv6.Is6() {
+			t.Errorf("BinToIPv6Format(%q) = %s, which is not IPv6", input, ipv6)
+		}
+	})
+}
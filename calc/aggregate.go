@@ -0,0 +1,166 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"sort"
+)
+
+// AggregatePrefixes returns the fewest prefixes that cover exactly the same
+// addresses as prefixes. Host bits are ignored, prefixes covered by another
+// one are dropped and sibling prefixes are merged into their parent until
+// nothing changes. IPv4 and IPv6 prefixes may be mixed.
+func AggregatePrefixes(prefixes []netip.Prefix) ([]netip.Prefix, []string, error) {
+	if len(prefixes) == 0 {
+		return nil, nil, fmt.Errorf("AggregatePrefixes: %w", ErrEmptyInput)
+	}
+
+	var steps []string
+
+	masked := make([]netip.Prefix, 0, len(prefixes))
+
+	for _, prefix := range prefixes {
+		if !prefix.IsValid() {
+			return nil, nil, fmt.Errorf("AggregatePrefixes: %w", ErrInvalidAddress)
+		}
+
+		if prefix != prefix.Masked() {
+			steps = append(steps, fmt.Sprintf("%s has host bits set, so it is taken as %s.", prefix, prefix.Masked()))
+		}
+
+		masked = append(masked, prefix.Masked())
+	}
+
+	sortPrefixes(masked)
+
+	var aggregated []netip.Prefix
+
+	for _, prefix := range masked {
+		if len(aggregated) > 0 && aggregated[len(aggregated)-1].Overlaps(prefix) {
+			steps = append(steps, fmt.Sprintf("%s is already covered by %s.", prefix, aggregated[len(aggregated)-1]))
+			continue
+		}
+
+		aggregated = append(aggregated, prefix)
+
+		for len(aggregated) >= 2 {
+			low, high := aggregated[len(aggregated)-2], aggregated[len(aggregated)-1]
+			if low.Bits() != high.Bits() || low.Bits() == 0 || low.Addr().BitLen() != high.Addr().BitLen() {
+				break
+			}
+
+			parent, _ := low.Addr().Prefix(low.Bits() - 1)
+			if parent.Addr() != low.Addr() || !parent.Contains(high.Addr()) {
+				break
+			}
+
+			steps = append(steps, fmt.Sprintf("%s and %s differ only in bit %d, so they merge into %s.", low, high, low.Bits()-1, parent))
+			aggregated = append(aggregated[:len(aggregated)-2], parent)
+		}
+	}
+
+	return aggregated, steps, nil
+}
+
+// Supernet returns the smallest single prefix that covers all of prefixes,
+// along with the prefixes of the address space it covers that none of
+// prefixes do.
+func Supernet(prefixes []netip.Prefix) (netip.Prefix, []netip.Prefix, []string, error) {
+	aggregated, _, err := AggregatePrefixes(prefixes)
+	if err != nil {
+		return netip.Prefix{}, nil, nil, fmt.Errorf("Supernet: %w", err)
+	}
+
+	bits := aggregated[0].Addr().BitLen()
+
+	for _, prefix := range aggregated {
+		if prefix.Addr().BitLen() != bits {
+			return netip.Prefix{}, nil, nil, fmt.Errorf("Supernet: %w", ErrFamilyMismatch)
+		}
+	}
+
+	first := addrToBig(aggregated[0].Addr())
+	_, lastAddr, _, _ := PrefixRange(aggregated[len(aggregated)-1])
+	last := addrToBig(lastAddr)
+
+	commonBits := bits - new(big.Int).Xor(first, last).BitLen()
+
+	var steps []string
+
+	for _, prefix := range aggregated {
+		steps = append(steps, fmt.Sprintf("%-20s %s", prefix, formatAddrBits(prefix.Addr())))
+	}
+
+	steps = append(steps, fmt.Sprintf("The lowest address %s and the highest address %s share the first %d bits.", aggregated[0].Addr(), lastAddr, commonBits))
+
+	supernet, _ := aggregated[0].Addr().Prefix(commonBits)
+	steps = append(steps, fmt.Sprintf("Keeping those %d bits and zeroing the rest gives the supernet %s.", commonBits, supernet))
+
+	var extra []netip.Prefix
+
+	cursor := addrToBig(supernet.Addr())
+	extraAddresses := new(big.Int)
+
+	for _, prefix := range append(aggregated, netip.Prefix{}) {
+		var gapEnd *big.Int
+
+		if prefix.IsValid() {
+			gapEnd = new(big.Int).Sub(addrToBig(prefix.Addr()), big.NewInt(1))
+		} else {
+			_, supernetLast, _, _ := PrefixRange(supernet)
+			gapEnd = addrToBig(supernetLast)
+		}
+
+		if cursor.Cmp(gapEnd) <= 0 {
+			gap, _, _ := RangeToCIDRs(bigToAddr(cursor, bits), bigToAddr(gapEnd, bits))
+			extra = append(extra, gap...)
+
+			extraAddresses.Add(extraAddresses, new(big.Int).Sub(gapEnd, cursor))
+			extraAddresses.Add(extraAddresses, big.NewInt(1))
+		}
+
+		if prefix.IsValid() {
+			_, prefixLast, _, _ := PrefixRange(prefix)
+			cursor = new(big.Int).Add(addrToBig(prefixLast), big.NewInt(1))
+		}
+	}
+
+	if len(extra) == 0 {
+		steps = append(steps, "The supernet covers exactly the given prefixes.")
+	} else {
+		steps = append(steps, fmt.Sprintf("The supernet also covers %s addresses that are not in any of the given prefixes.", extraAddresses))
+	}
+
+	return supernet, extra, steps, nil
+}
+
+// formatAddrBits formats an address as binary octets separated by dots, so
+// that common leading bits can be compared by eye.
+func formatAddrBits(ipAddress netip.Addr) string {
+	var text []byte
+
+	for i, octet := range ipAddress.AsSlice() {
+		if i > 0 {
+			text = append(text, '.')
+		}
+
+		text = append(text, fmt.Sprintf("%08b", octet)...)
+	}
+
+	return string(text)
+}
+
+func sortPrefixes(prefixes []netip.Prefix) {
+	sort.Slice(prefixes, func(i, j int) bool {
+		if prefixes[i].Addr().BitLen() != prefixes[j].Addr().BitLen() {
+			return prefixes[i].Addr().BitLen() < prefixes[j].Addr().BitLen()
+		}
+
+		if prefixes[i].Addr() != prefixes[j].Addr() {
+			return prefixes[i].Addr().Less(prefixes[j].Addr())
+		}
+
+		return prefixes[i].Bits() < prefixes[j].Bits()
+	})
+}
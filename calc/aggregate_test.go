@@ -0,0 +1,113 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"testing"
+	"testing/quick"
+)
+
+func parsePrefixes(texts ...string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(texts))
+
+	for _, text := range texts {
+		prefixes = append(prefixes, netip.MustParsePrefix(text))
+	}
+
+	return prefixes
+}
+
+func TestAggregatePrefixes(t *testing.T) {
+	tests := []struct {
+		input []string
+		want  string
+	}{
+		{[]string{"10.0.0.0/24", "10.0.1.0/24"}, "[10.0.0.0/23]"},
+		{[]string{"10.0.2.0/23", "10.0.1.0/24", "10.0.0.0/24"}, "[10.0.0.0/22]"},
+		{[]string{"10.0.0.0/25", "10.0.0.128/26", "10.0.0.192/26"}, "[10.0.0.0/24]"},
+		{[]string{"10.0.1.0/24", "10.0.2.0/24"}, "[10.0.1.0/24 10.0.2.0/24]"},
+		{[]string{"10.0.0.0/16", "10.0.5.0/24", "10.0.0.0/16"}, "[10.0.0.0/16]"},
+		{[]string{"192.168.1.77/24", "192.168.0.1/24"}, "[192.168.0.0/23]"},
+		{[]string{"0.0.0.0/1", "128.0.0.0/1"}, "[0.0.0.0/0]"},
+		{[]string{"2001:db8::/33", "2001:db8:8000::/33", "10.0.0.0/8"}, "[10.0.0.0/8 2001:db8::/32]"},
+	}
+
+	for _, test := range tests {
+		got, _, err := AggregatePrefixes(parsePrefixes(test.input...))
+		if err != nil || fmt.Sprint(got) != test.want {
+			t.Errorf("AggregatePrefixes(%v) = %v, %v, want %s", test.input, got, err, test.want)
+		}
+	}
+
+	if _, _, err := AggregatePrefixes(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("AggregatePrefixes(nil) error = %v, want %v", err, ErrEmptyInput)
+	}
+}
+
+func TestAggregatePrefixesPreservesAddresses(t *testing.T) {
+	preserves := func(starts []uint16) bool {
+		if len(starts) == 0 {
+			return true
+		}
+
+		prefixes := make([]netip.Prefix, 0, len(starts))
+		covered := make(map[uint32]bool)
+
+		for _, start := range starts {
+			prefix := netip.PrefixFrom(uint32ToIPv4(uint32(start)<<8), 24)
+			prefixes = append(prefixes, prefix)
+			covered[uint32(start)] = true
+		}
+
+		aggregated, _, err := AggregatePrefixes(prefixes)
+		if err != nil {
+			return false
+		}
+
+		count := 0
+
+		for _, prefix := range aggregated {
+			first, last, _, _ := PrefixRange(prefix)
+
+			for block := ipv4ToUint32(first) >> 8; block <= ipv4ToUint32(last)>>8; block++ {
+				if !covered[block] {
+					return false
+				}
+
+				count++
+			}
+		}
+
+		return count == len(covered)
+	}
+
+	if err := quick.Check(preserves, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSupernet(t *testing.T) {
+	tests := []struct {
+		input     []string
+		want      string
+		wantExtra string
+	}{
+		{[]string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"}, "10.0.0.0/22", "[]"},
+		{[]string{"10.0.1.0/24", "10.0.2.0/24"}, "10.0.0.0/22", "[10.0.0.0/24 10.0.3.0/24]"},
+		{[]string{"172.16.5.0/24", "172.16.9.0/24"}, "172.16.0.0/20", "[172.16.0.0/22 172.16.4.0/24 172.16.6.0/23 172.16.8.0/24 172.16.10.0/23 172.16.12.0/22]"},
+		{[]string{"192.168.1.0/24"}, "192.168.1.0/24", "[]"},
+		{[]string{"2001:db8:1::/48", "2001:db8:2::/48"}, "2001:db8::/46", "[2001:db8::/48 2001:db8:3::/48]"},
+	}
+
+	for _, test := range tests {
+		got, extra, steps, err := Supernet(parsePrefixes(test.input...))
+		if err != nil || got.String() != test.want || fmt.Sprint(extra) != test.wantExtra || len(steps) == 0 {
+			t.Errorf("Supernet(%v) = %s, %v, %v, want %s, %s", test.input, got, extra, err, test.want, test.wantExtra)
+		}
+	}
+
+	if _, _, _, err := Supernet(parsePrefixes("10.0.0.0/8", "2001:db8::/32")); !errors.Is(err, ErrFamilyMismatch) {
+		t.Errorf("Supernet(mixed families) error = %v, want %v", err, ErrFamilyMismatch)
+	}
+}
@@ -0,0 +1,203 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+type BitwiseOperator int
+
+const (
+	OpAND BitwiseOperator = iota
+	OpOR
+	OpXOR
+	OpNAND
+	OpNOR
+	OpNOT
+	OpShiftLeft
+	OpShiftRight
+	OpArithmeticShiftLeft
+	OpArithmeticShiftRight
+	OpRotateLeft
+	OpRotateRight
+)
+
+var BitwiseOperators = []BitwiseOperator{
+	OpAND, OpOR, OpXOR, OpNAND, OpNOR, OpNOT,
+	OpShiftLeft, OpShiftRight, OpArithmeticShiftLeft, OpArithmeticShiftRight, OpRotateLeft, OpRotateRight,
+}
+
+var BitwiseWidths = []int{8, 16, 32, 64, 128}
+
+var bitwiseOperatorNames = map[BitwiseOperator]string{
+	OpAND:                  "AND",
+	OpOR:                   "OR",
+	OpXOR:                  "XOR",
+	OpNAND:                 "NAND",
+	OpNOR:                  "NOR",
+	OpNOT:                  "NOT",
+	OpShiftLeft:            "SHL",
+	OpShiftRight:           "SHR",
+	OpArithmeticShiftLeft:  "SAL",
+	OpArithmeticShiftRight: "SAR",
+	OpRotateLeft:           "ROL",
+	OpRotateRight:          "ROR",
+}
+
+func (op BitwiseOperator) String() string {
+	if name, ok := bitwiseOperatorNames[op]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("BitwiseOperator(%d)", int(op))
+}
+
+// Unary reports whether the operator takes a single operand.
+func (op BitwiseOperator) Unary() bool {
+	return op == OpNOT
+}
+
+// Shift reports whether the second operand is a bit count rather than a
+// value of the selected width.
+func (op BitwiseOperator) Shift() bool {
+	return op >= OpShiftLeft && op <= OpRotateRight
+}
+
+func ParseBitwiseOperator(name string) (BitwiseOperator, error) {
+	for op, opName := range bitwiseOperatorNames {
+		if strings.EqualFold(opName, strings.TrimSpace(name)) {
+			return op, nil
+		}
+	}
+
+	return 0, fmt.Errorf("ParseBitwiseOperator: %q: %w", name, ErrUnknownOperator)
+}
+
+// ParseBitwiseOperand parses a binary ("0b" prefix), hexadecimal ("0x"
+// prefix) or decimal operand and returns its unsigned value at the given
+// width. Negative decimals are stored in two's complement.
+func ParseBitwiseOperand(operand string, width int) (*big.Int, error) {
+	if err := checkBitwiseWidth(width); err != nil {
+		return nil, fmt.Errorf("ParseBitwiseOperand: %w", err)
+	}
+
+	trimmedOperand := strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(operand))
+	negative := strings.HasPrefix(trimmedOperand, "-")
+	trimmedOperand = strings.TrimPrefix(trimmedOperand, "-")
+
+	base, invalidErr := 10, ErrInvalidDec
+
+	switch {
+	case strings.HasPrefix(trimmedOperand, "0b"):
+		base, invalidErr = 2, ErrInvalidBin
+		trimmedOperand = trimmedOperand[2:]
+	case strings.HasPrefix(trimmedOperand, "0x"):
+		base, invalidErr = 16, ErrInvalidHex
+		trimmedOperand = trimmedOperand[2:]
+	}
+
+	value, ok := new(big.Int).SetString(trimmedOperand, base)
+	if !ok || strings.HasPrefix(trimmedOperand, "+") || strings.HasPrefix(trimmedOperand, "-") {
+		return nil, fmt.Errorf("ParseBitwiseOperand: %w", invalidErr)
+	}
+
+	if negative {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(width-1))
+		if value.Cmp(limit) > 0 {
+			return nil, fmt.Errorf("ParseBitwiseOperand: %s does not fit in %d bits: %w", operand, width, ErrOutOfRange)
+		}
+
+		return value.Neg(value).And(value, bitwiseMask(width)), nil
+	}
+
+	if value.BitLen() > width {
+		return nil, fmt.Errorf("ParseBitwiseOperand: %s does not fit in %d bits: %w", operand, width, ErrOutOfRange)
+	}
+
+	return value, nil
+}
+
+// ApplyBitwise applies op to a and b at the given width. For shifts and
+// rotates b is the number of bit positions, and it is ignored by NOT.
+func ApplyBitwise(op BitwiseOperator, a *big.Int, b *big.Int, width int) (*big.Int, error) {
+	if err := checkBitwiseWidth(width); err != nil {
+		return nil, fmt.Errorf("ApplyBitwise: %w", err)
+	}
+
+	mask := bitwiseMask(width)
+	result := new(big.Int)
+
+	var count uint
+
+	if op.Shift() {
+		if !b.IsUint64() || b.Uint64() > uint64(width) {
+			return nil, fmt.Errorf("ApplyBitwise: shift count must be between 0 and %d: %w", width, ErrOutOfRange)
+		}
+
+		count = uint(b.Uint64())
+	}
+
+	switch op {
+	case OpAND:
+		result.And(a, b)
+	case OpOR:
+		result.Or(a, b)
+	case OpXOR:
+		result.Xor(a, b)
+	case OpNAND:
+		result.And(a, b).Xor(result, mask)
+	case OpNOR:
+		result.Or(a, b).Xor(result, mask)
+	case OpNOT:
+		result.Xor(a, mask)
+	case OpShiftLeft, OpArithmeticShiftLeft:
+		result.Lsh(a, count)
+	case OpShiftRight:
+		result.Rsh(a, count)
+	case OpArithmeticShiftRight:
+		result.Rsh(a, count)
+
+		if a.Bit(width-1) == 1 {
+			signBits := new(big.Int).Rsh(mask, count)
+			result.Or(result, signBits.Xor(signBits, mask))
+		}
+	case OpRotateLeft, OpRotateRight:
+		count %= uint(width)
+		if op == OpRotateRight {
+			count = (uint(width) - count) % uint(width)
+		}
+
+		result.Lsh(a, count).Or(result, new(big.Int).Rsh(a, uint(width)-count))
+	default:
+		return nil, fmt.Errorf("ApplyBitwise: %s: %w", op, ErrUnknownOperator)
+	}
+
+	return result.And(result, mask), nil
+}
+
+// FormatBitwiseBin formats value as width binary digits in nibbles.
+func FormatBitwiseBin(value *big.Int, width int) string {
+	return FormatBinInNimbles(fmt.Sprintf("%0*b", width, value))
+}
+
+// FormatBitwiseHex formats value as width/4 hexadecimal digits.
+func FormatBitwiseHex(value *big.Int, width int) string {
+	return fmt.Sprintf("%0*X", (width+3)/4, value)
+}
+
+func bitwiseMask(width int) *big.Int {
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(width))
+
+	return mask.Sub(mask, big.NewInt(1))
+}
+
+func checkBitwiseWidth(width int) error {
+	for _, supported := range BitwiseWidths {
+		if width == supported {
+			return nil
+		}
+	}
+
+	return ErrInvalidWidth
+}
@@ -0,0 +1,121 @@
+package calc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParseBitwiseOperand(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		want  string
+		err   error
+	}{
+		{"0b1010", 8, "10", nil},
+		{"0b 1111 0000", 8, "240", nil},
+		{"0xFF", 8, "255", nil},
+		{"255", 8, "255", nil},
+		{"-1", 8, "255", nil},
+		{"-128", 8, "128", nil},
+		{"-129", 8, "", ErrOutOfRange},
+		{"256", 8, "", ErrOutOfRange},
+		{"0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF", 128, "340282366920938463463374607431768211455", nil},
+		{"0b102", 8, "", ErrInvalidBin},
+		{"0xG", 8, "", ErrInvalidHex},
+		{"12a", 8, "", ErrInvalidDec},
+		{"--1", 8, "", ErrInvalidDec},
+		{"1", 12, "", ErrInvalidWidth},
+	}
+
+	for _, test := range tests {
+		got, err := ParseBitwiseOperand(test.input, test.width)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseBitwiseOperand(%q, %d) error = %v, want %v", test.input, test.width, err, test.err)
+			continue
+		}
+
+		if test.err == nil && got.String() != test.want {
+			t.Errorf("ParseBitwiseOperand(%q, %d) = %s, want %s", test.input, test.width, got, test.want)
+		}
+	}
+}
+
+func TestApplyBitwise(t *testing.T) {
+	tests := []struct {
+		op    BitwiseOperator
+		a     int64
+		b     int64
+		width int
+		want  int64
+	}{
+		{OpAND, 0b1100, 0b1010, 8, 0b1000},
+		{OpOR, 0b1100, 0b1010, 8, 0b1110},
+		{OpXOR, 0b1100, 0b1010, 8, 0b0110},
+		{OpNAND, 0b1100, 0b1010, 8, 0b11110111},
+		{OpNOR, 0b1100, 0b1010, 8, 0b11110001},
+		{OpNOT, 0b00001111, 0, 8, 0b11110000},
+		{OpShiftLeft, 0b10000001, 1, 8, 0b00000010},
+		{OpArithmeticShiftLeft, 0b10000001, 1, 8, 0b00000010},
+		{OpShiftRight, 0b10000001, 1, 8, 0b01000000},
+		{OpArithmeticShiftRight, 0b10000001, 2, 8, 0b11100000},
+		{OpArithmeticShiftRight, 0b01000001, 2, 8, 0b00010000},
+		{OpArithmeticShiftRight, 0b10000000, 8, 8, 0b11111111},
+		{OpRotateLeft, 0b10000001, 1, 8, 0b00000011},
+		{OpRotateRight, 0b10000001, 1, 8, 0b11000000},
+		{OpRotateLeft, 0b10000001, 8, 8, 0b10000001},
+		{OpShiftLeft, 0xFFFF, 4, 16, 0xFFF0},
+	}
+
+	for _, test := range tests {
+		got, err := ApplyBitwise(test.op, big.NewInt(test.a), big.NewInt(test.b), test.width)
+		if err != nil || got.Int64() != test.want {
+			t.Errorf("ApplyBitwise(%s, %b, %d, %d) = %b, %v, want %b", test.op, test.a, test.b, test.width, got, err, test.want)
+		}
+	}
+
+	if _, err := ApplyBitwise(OpShiftLeft, big.NewInt(1), big.NewInt(9), 8); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ApplyBitwise(SHL, 1, 9, 8) error = %v, want %v", err, ErrOutOfRange)
+	}
+}
+
+func TestParseBitwiseOperator(t *testing.T) {
+	for _, op := range BitwiseOperators {
+		got, err := ParseBitwiseOperator(op.String())
+		if err != nil || got != op {
+			t.Errorf("ParseBitwiseOperator(%q) = %s, %v, want %s", op.String(), got, err, op)
+		}
+	}
+
+	if _, err := ParseBitwiseOperator("IMPLY"); !errors.Is(err, ErrUnknownOperator) {
+		t.Errorf("ParseBitwiseOperator(IMPLY) error = %v, want %v", err, ErrUnknownOperator)
+	}
+}
+
+func TestFormatBitwise(t *testing.T) {
+	value := big.NewInt(0x2A)
+
+	if got := FormatBitwiseBin(value, 16); got != "0000 0000 0010 1010" {
+		t.Errorf("FormatBitwiseBin(0x2A, 16) = %q", got)
+	}
+
+	if got := FormatBitwiseHex(value, 16); got != "002A" {
+		t.Errorf("FormatBitwiseHex(0x2A, 16) = %q", got)
+	}
+}
+
+func FuzzParseBitwiseOperand(f *testing.F) {
+	f.Add("0b1010", uint8(0))
+	f.Add("-0x80", uint8(0))
+	f.Add("123456789", uint8(2))
+
+	f.Fuzz(func(t *testing.T, input string, widthIndex uint8) {
+		width := BitwiseWidths[int(widthIndex)%len(BitwiseWidths)]
+
+		value, err := ParseBitwiseOperand(input, width)
+		if err == nil && (value.Sign() < 0 || value.BitLen() > width) {
+			t.Errorf("ParseBitwiseOperand(%q, %d) = %s, which does not fit", input, width, value)
+		}
+	})
+}
@@ -0,0 +1,229 @@
+package calc
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+type BogonCategory struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+var BogonCategories = []BogonCategory{
+	{"unspecified", "\"this network\" and the unspecified address", true},
+	{"private", "private-use (RFC 1918) and unique-local addresses", true},
+	{"shared", "shared address space for carrier-grade NAT (RFC 6598)", true},
+	{"loopback", "loopback addresses", true},
+	{"link-local", "link-local addresses", true},
+	{"documentation", "documentation prefixes (TEST-NET-1/2/3, 2001:db8::/32)", true},
+	{"benchmarking", "benchmarking prefixes (RFC 2544, RFC 5180)", true},
+	{"reserved", "reserved 240.0.0.0/4 and limited broadcast", true},
+	{"protocol", "IETF protocol assignments that are not globally reachable", true},
+	{"multicast", "multicast, which is only a bogon as a source address", false},
+}
+
+var BogonFilterFormats = []string{"cisco", "juniper", "nftables", "text"}
+
+// multicastBogons are not in the special-purpose registries, which do not
+// cover multicast.
+var multicastBogons = []netip.Prefix{
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+var bogonCategoryOfBlock = map[string]string{
+	"This network":               "unspecified",
+	"This host on this network":  "unspecified",
+	"Unspecified Address":        "unspecified",
+	"Private-Use":                "private",
+	"Unique-Local":               "private",
+	"Shared Address Space":       "shared",
+	"Loopback":                   "loopback",
+	"Loopback Address":           "loopback",
+	"Link Local":                 "link-local",
+	"Link-Local Unicast":         "link-local",
+	"Documentation":              "documentation",
+	"Documentation (TEST-NET-1)": "documentation",
+	"Documentation (TEST-NET-2)": "documentation",
+	"Documentation (TEST-NET-3)": "documentation",
+	"Benchmarking":               "benchmarking",
+	"Reserved":                   "reserved",
+	"Limited Broadcast":          "reserved",
+}
+
+// BogonPrefixes returns the bogon prefixes of the given categories, IPv4
+// first, without prefixes that are covered by another one in the list.
+//
+// The prefixes come from the embedded special-purpose registries: a block is
+// a bogon if it is not globally reachable and does not contain a globally
+// reachable block, so 192.0.0.0/24 is left out for the sake of the anycast
+// addresses in it, but its local-only sub-blocks are not.
+func BogonPrefixes(categories []string) ([]netip.Prefix, error) {
+	selected := make(map[string]bool, len(categories))
+
+	for _, category := range categories {
+		if !isBogonCategory(category) {
+			return nil, fmt.Errorf("BogonPrefixes: %q: %w", category, ErrUnknownCategory)
+		}
+
+		selected[category] = true
+	}
+
+	blocks := SpecialPurposeBlocks()
+
+	var prefixes []netip.Prefix
+
+	for _, block := range blocks {
+		category, ok := bogonCategoryOfBlock[block.Name]
+		if !ok {
+			category = "protocol"
+		}
+
+		if selected[category] && block.GloballyReachable == FlagFalse && !containsReachableBlock(block.Prefix, blocks) {
+			prefixes = append(prefixes, block.Prefix)
+		}
+	}
+
+	if selected["multicast"] {
+		prefixes = append(prefixes, multicastBogons...)
+	}
+
+	return removeCoveredPrefixes(prefixes), nil
+}
+
+// FormatBogonFilter exports prefixes as a filter that rejects them. name is
+// the name of the prefix list, policy or nftables set.
+func FormatBogonFilter(prefixes []netip.Prefix, format string, name string) (string, error) {
+	var ipv4Prefixes, ipv6Prefixes []netip.Prefix
+
+	for _, prefix := range prefixes {
+		if prefix.Addr().Is4() {
+			ipv4Prefixes = append(ipv4Prefixes, prefix)
+		} else {
+			ipv6Prefixes = append(ipv6Prefixes, prefix)
+		}
+	}
+
+	var buf strings.Builder
+
+	switch format {
+	case "cisco":
+		for i, prefix := range ipv4Prefixes {
+			fmt.Fprintf(&buf, "ip prefix-list %s seq %d deny %s%s\n", name, (i+1)*5, prefix, ciscoOrLonger(prefix))
+		}
+
+		if len(ipv4Prefixes) > 0 {
+			fmt.Fprintf(&buf, "ip prefix-list %s seq %d permit 0.0.0.0/0 le 32\n", name, (len(ipv4Prefixes)+1)*5)
+		}
+
+		for i, prefix := range ipv6Prefixes {
+			fmt.Fprintf(&buf, "ipv6 prefix-list %s-V6 seq %d deny %s%s\n", name, (i+1)*5, prefix, ciscoOrLonger(prefix))
+		}
+
+		if len(ipv6Prefixes) > 0 {
+			fmt.Fprintf(&buf, "ipv6 prefix-list %s-V6 seq %d permit ::/0 le 128\n", name, (len(ipv6Prefixes)+1)*5)
+		}
+	case "juniper":
+		fmt.Fprintf(&buf, "policy-options {\n    prefix-list %s {\n", name)
+
+		for _, prefix := range prefixes {
+			fmt.Fprintf(&buf, "        %s;\n", prefix)
+		}
+
+		fmt.Fprintf(&buf, "    }\n    policy-statement REJECT-%s {\n", name)
+		fmt.Fprintf(&buf, "        term bogons {\n            from {\n                prefix-list-filter %s orlonger;\n            }\n", name)
+		buf.WriteString("            then reject;\n        }\n    }\n}\n")
+	case "nftables":
+		setName := strings.ToLower(name)
+
+		for _, set := range []struct {
+			suffix   string
+			addrType string
+			prefixes []netip.Prefix
+		}{
+			{"_v4", "ipv4_addr", ipv4Prefixes},
+			{"_v6", "ipv6_addr", ipv6Prefixes},
+		} {
+			if len(set.prefixes) == 0 {
+				continue
+			}
+
+			elements := make([]string, 0, len(set.prefixes))
+			for _, prefix := range set.prefixes {
+				elements = append(elements, prefix.String())
+			}
+
+			fmt.Fprintf(&buf, "set %s%s {\n    type %s\n    flags interval\n    elements = {\n        %s\n    }\n}\n",
+				setName, set.suffix, set.addrType, strings.Join(elements, ",\n        "))
+		}
+	case "text":
+		for _, prefix := range prefixes {
+			fmt.Fprintln(&buf, prefix)
+		}
+	default:
+		return "", fmt.Errorf("FormatBogonFilter: %q: %w", format, ErrUnknownFormat)
+	}
+
+	return buf.String(), nil
+}
+
+func DefaultBogonCategories() []string {
+	var categories []string
+
+	for _, category := range BogonCategories {
+		if category.Default {
+			categories = append(categories, category.Name)
+		}
+	}
+
+	return categories
+}
+
+// ciscoOrLonger returns the "le" clause that makes a prefix-list entry match
+// the prefix and everything more specific. IOS rejects it on host routes.
+func ciscoOrLonger(prefix netip.Prefix) string {
+	if prefix.IsSingleIP() {
+		return ""
+	}
+
+	return fmt.Sprintf(" le %d", prefix.Addr().BitLen())
+}
+
+func isBogonCategory(name string) bool {
+	for _, category := range BogonCategories {
+		if category.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsReachableBlock(prefix netip.Prefix, blocks []SpecialPurposeBlock) bool {
+	for _, block := range blocks {
+		if block.GloballyReachable == FlagTrue && block.Prefix.Bits() > prefix.Bits() && prefix.Contains(block.Prefix.Addr()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeCoveredPrefixes(prefixes []netip.Prefix) []netip.Prefix {
+	sortPrefixes(prefixes)
+
+	var result []netip.Prefix
+
+	for _, prefix := range prefixes {
+		if len(result) > 0 && result[len(result)-1].Overlaps(prefix) {
+			continue
+		}
+
+		result = append(result, prefix)
+	}
+
+	return result
+}
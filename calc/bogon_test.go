@@ -0,0 +1,125 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"testing"
+)
+
+func TestBogonPrefixes(t *testing.T) {
+	prefixes, err := BogonPrefixes(DefaultBogonCategories())
+	if err != nil {
+		t.Fatalf("BogonPrefixes(default) error = %v", err)
+	}
+
+	got := make(map[string]bool, len(prefixes))
+	for _, prefix := range prefixes {
+		got[prefix.String()] = true
+	}
+
+	for _, want := range []string{
+		"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16", "172.16.0.0/12",
+		"192.0.0.0/29", "192.0.0.8/32", "192.0.0.170/32", "192.0.2.0/24", "192.168.0.0/16", "198.18.0.0/15",
+		"198.51.100.0/24", "203.0.113.0/24", "240.0.0.0/4",
+		"::/128", "::1/128", "::ffff:0.0.0.0/96", "64:ff9b:1::/48", "100::/64", "2001:2::/48", "2001:db8::/32", "fc00::/7", "fe80::/10",
+	} {
+		if !got[want] {
+			t.Errorf("BogonPrefixes(default) is missing %s", want)
+		}
+	}
+
+	for _, unwanted := range []string{
+		"0.0.0.0/32", "255.255.255.255/32", "192.0.0.0/24", "192.0.0.9/32", "2001::/23", "2001::/32", "2002::/16",
+		"64:ff9b::/96", "192.88.99.0/24", "224.0.0.0/4", "ff00::/8",
+	} {
+		if got[unwanted] {
+			t.Errorf("BogonPrefixes(default) includes %s", unwanted)
+		}
+	}
+
+	for i := 1; i < len(prefixes); i++ {
+		if prefixes[i-1].Overlaps(prefixes[i]) {
+			t.Errorf("BogonPrefixes(default) has overlapping %s and %s", prefixes[i-1], prefixes[i])
+		}
+	}
+}
+
+func TestBogonPrefixesByCategory(t *testing.T) {
+	prefixes, err := BogonPrefixes([]string{"private", "multicast"})
+	if err != nil {
+		t.Fatalf("BogonPrefixes(private, multicast) error = %v", err)
+	}
+
+	want := "[10.0.0.0/8 172.16.0.0/12 192.168.0.0/16 224.0.0.0/4 fc00::/7 ff00::/8]"
+	if got := fmt.Sprint(prefixes); got != want {
+		t.Errorf("BogonPrefixes(private, multicast) = %s, want %s", got, want)
+	}
+
+	if _, err := BogonPrefixes([]string{"private", "bogus"}); !errors.Is(err, ErrUnknownCategory) {
+		t.Errorf("BogonPrefixes(bogus) error = %v, want %v", err, ErrUnknownCategory)
+	}
+}
+
+func TestFormatBogonFilter(t *testing.T) {
+	prefixes := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fc00::/7")}
+	hostPrefixes := []netip.Prefix{netip.MustParsePrefix("192.0.0.8/32"), netip.MustParsePrefix("::1/128")}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"cisco", "ip prefix-list BOGONS seq 5 deny 10.0.0.0/8 le 32\n" +
+			"ip prefix-list BOGONS seq 10 permit 0.0.0.0/0 le 32\n" +
+			"ipv6 prefix-list BOGONS-V6 seq 5 deny fc00::/7 le 128\n" +
+			"ipv6 prefix-list BOGONS-V6 seq 10 permit ::/0 le 128\n"},
+		{"juniper", "policy-options {\n" +
+			"    prefix-list BOGONS {\n" +
+			"        10.0.0.0/8;\n" +
+			"        fc00::/7;\n" +
+			"    }\n" +
+			"    policy-statement REJECT-BOGONS {\n" +
+			"        term bogons {\n" +
+			"            from {\n" +
+			"                prefix-list-filter BOGONS orlonger;\n" +
+			"            }\n" +
+			"            then reject;\n" +
+			"        }\n" +
+			"    }\n" +
+			"}\n"},
+		{"nftables", "set bogons_v4 {\n" +
+			"    type ipv4_addr\n" +
+			"    flags interval\n" +
+			"    elements = {\n" +
+			"        10.0.0.0/8\n" +
+			"    }\n" +
+			"}\n" +
+			"set bogons_v6 {\n" +
+			"    type ipv6_addr\n" +
+			"    flags interval\n" +
+			"    elements = {\n" +
+			"        fc00::/7\n" +
+			"    }\n" +
+			"}\n"},
+		{"text", "10.0.0.0/8\nfc00::/7\n"},
+	}
+
+	for _, test := range tests {
+		got, err := FormatBogonFilter(prefixes, test.format, "BOGONS")
+		if err != nil || got != test.want {
+			t.Errorf("FormatBogonFilter(%s) = %q, %v, want %q", test.format, got, err, test.want)
+		}
+	}
+
+	want := "ip prefix-list BOGONS seq 5 deny 192.0.0.8/32\n" +
+		"ip prefix-list BOGONS seq 10 permit 0.0.0.0/0 le 32\n" +
+		"ipv6 prefix-list BOGONS-V6 seq 5 deny ::1/128\n" +
+		"ipv6 prefix-list BOGONS-V6 seq 10 permit ::/0 le 128\n"
+	if got, err := FormatBogonFilter(hostPrefixes, "cisco", "BOGONS"); err != nil || got != want {
+		t.Errorf("FormatBogonFilter(host routes, cisco) = %q, %v, want %q", got, err, want)
+	}
+
+	if _, err := FormatBogonFilter(prefixes, "pf", "BOGONS"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("FormatBogonFilter(pf) error = %v, want %v", err, ErrUnknownFormat)
+	}
+}
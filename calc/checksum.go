@@ -0,0 +1,89 @@
+package calc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+func ParseHexBytes(hexBytes string) ([]byte, error) {
+	trimmedHexBytes := strings.NewReplacer(" ", "", ":", "", "\t", "", "\n", "").Replace(hexBytes)
+	if trimmedHexBytes == "" {
+		return nil, fmt.Errorf("ParseHexBytes: %w", ErrEmptyInput)
+	}
+
+	data, err := hex.DecodeString(trimmedHexBytes)
+	if err != nil {
+		return nil, fmt.Errorf("ParseHexBytes: %w", ErrInvalidHex)
+	}
+
+	return data, nil
+}
+
+func InternetChecksum(data []byte) (uint16, []string) {
+	sum, steps := onesComplementSum(data)
+	checksum := ^sum
+
+	steps = append(steps, fmt.Sprintf("Take the one's complement of 0x%04X to get the checksum 0x%04X.", sum, checksum))
+
+	return checksum, steps
+}
+
+// VerifyInternetChecksum reports whether data, which includes its checksum,
+// sums to 0xFFFF.
+func VerifyInternetChecksum(data []byte) (bool, []string) {
+	sum, steps := onesComplementSum(data)
+	valid := sum == 0xFFFF
+
+	if valid {
+		steps = append(steps, fmt.Sprintf("The sum including the checksum is 0x%04X, so the data is intact.", sum))
+	} else {
+		steps = append(steps, fmt.Sprintf("The sum including the checksum is 0x%04X instead of 0xFFFF, so the data is corrupted.", sum))
+	}
+
+	return valid, steps
+}
+
+// onesComplementSum adds data as big-endian 16-bit words following RFC 1071,
+// folding every carry out of bit 15 back into the low bit.
+func onesComplementSum(data []byte) (uint16, []string) {
+	var steps []string
+
+	if len(data)%2 != 0 {
+		data = append(data[:len(data):len(data)], 0)
+		steps = append(steps, "The data has an odd number of bytes, so pad it with a trailing 00 byte.")
+	}
+
+	words := make([]uint16, 0, len(data)/2)
+	wordStrs := make([]string, 0, len(data)/2)
+
+	for i := 0; i < len(data); i += 2 {
+		word := uint16(data[i])<<8 | uint16(data[i+1])
+		words = append(words, word)
+		wordStrs = append(wordStrs, fmt.Sprintf("%04X", word))
+	}
+
+	if len(words) == 0 {
+		return 0, append(steps, "There is no data, so the sum is 0x0000.")
+	}
+
+	steps = append(steps, fmt.Sprintf("Split the data into %d 16-bit words: %s.", len(words), strings.Join(wordStrs, " ")))
+
+	sum := uint32(words[0])
+	steps = append(steps, fmt.Sprintf("Start with the first word 0x%04X.", sum))
+
+	for _, word := range words[1:] {
+		total := sum + uint32(word)
+
+		if total > 0xFFFF {
+			wrapped := (total & 0xFFFF) + (total >> 16)
+			steps = append(steps, fmt.Sprintf("0x%04X + 0x%04X = 0x%05X, wrap the carry around: 0x%04X + 0x1 = 0x%04X.", sum, word, total, total&0xFFFF, wrapped))
+			sum = wrapped
+		} else {
+			steps = append(steps, fmt.Sprintf("0x%04X + 0x%04X = 0x%04X.", sum, word, total))
+			sum = total
+		}
+	}
+
+	return uint16(sum), steps
+}
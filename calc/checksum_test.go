@@ -0,0 +1,106 @@
+package calc
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/quick"
+)
+
+func TestParseHexBytes(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []byte
+		err   error
+	}{
+		{"4500 0073", []byte{0x45, 0x00, 0x00, 0x73}, nil},
+		{"de:ad:be:ef", []byte{0xDE, 0xAD, 0xBE, 0xEF}, nil},
+		{"0a\n0b", []byte{0x0A, 0x0B}, nil},
+		{"abc", nil, ErrInvalidHex},
+		{"zz", nil, ErrInvalidHex},
+		{" ", nil, ErrEmptyInput},
+	}
+
+	for _, test := range tests {
+		got, err := ParseHexBytes(test.input)
+		if !errors.Is(err, test.err) || !bytes.Equal(got, test.want) {
+			t.Errorf("ParseHexBytes(%q) = %X, %v, want %X, %v", test.input, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestInternetChecksum(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint16
+	}{
+		{"4500 0073 0000 4000 4011 0000 c0a8 0001 c0a8 00c7", 0xB861},
+		{"0001 f203 f4f5 f6f7", 0x220D},
+		{"01 02 03", 0xFBFD},
+		{"ffff", 0x0000},
+	}
+
+	for _, test := range tests {
+		data, err := ParseHexBytes(test.input)
+		if err != nil {
+			t.Fatalf("ParseHexBytes(%q) error = %v", test.input, err)
+		}
+
+		got, steps := InternetChecksum(data)
+		if got != test.want || len(steps) == 0 {
+			t.Errorf("InternetChecksum(%q) = %04X with %d steps, want %04X", test.input, got, len(steps), test.want)
+		}
+	}
+
+	if got, _ := InternetChecksum(nil); got != 0xFFFF {
+		t.Errorf("InternetChecksum(nil) = %04X, want FFFF", got)
+	}
+}
+
+func TestVerifyInternetChecksum(t *testing.T) {
+	valid, _ := VerifyInternetChecksum([]byte{0x45, 0x00, 0x00, 0x73, 0x00, 0x00, 0x40, 0x00, 0x40, 0x11, 0xB8, 0x61, 0xC0, 0xA8, 0x00, 0x01, 0xC0, 0xA8, 0x00, 0xC7})
+	if !valid {
+		t.Error("VerifyInternetChecksum of a correct IPv4 header = false, want true")
+	}
+
+	valid, _ = VerifyInternetChecksum([]byte{0x45, 0x00, 0x00, 0x73, 0x00, 0x00, 0x40, 0x00, 0x40, 0x11, 0xB8, 0x62, 0xC0, 0xA8, 0x00, 0x01, 0xC0, 0xA8, 0x00, 0xC7})
+	if valid {
+		t.Error("VerifyInternetChecksum of a corrupted IPv4 header = true, want false")
+	}
+}
+
+func TestInternetChecksumVerifies(t *testing.T) {
+	property := func(data []byte) bool {
+		if len(data)%2 != 0 {
+			data = append(data, 0)
+		}
+
+		checksum, _ := InternetChecksum(data)
+		valid, _ := VerifyInternetChecksum(append(data, byte(checksum>>8), byte(checksum)))
+
+		return valid
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzParseHexBytes(f *testing.F) {
+	f.Add("4500 0073")
+	f.Add("de:ad:be:ef")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		data, err := ParseHexBytes(input)
+		if err != nil {
+			return
+		}
+
+		checksum, _ := InternetChecksum(data)
+		if len(data)%2 == 0 {
+			if valid, _ := VerifyInternetChecksum(append(data, byte(checksum>>8), byte(checksum))); !valid {
+				t.Errorf("checksum %04X of %X does not verify", checksum, data)
+			}
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package calc
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+func IsPrivateIP(ipAddress netip.Addr) bool {
+	return ipAddress.IsPrivate()
+}
+
+func IsLoopbackIP(ipAddress netip.Addr) bool {
+	return ipAddress.IsLoopback()
+}
+
+func IsLinkLocalUnicastIP(ipAddress netip.Addr) bool {
+	return ipAddress.IsLinkLocalUnicast()
+}
+
+func IsMulticastIP(ipAddress netip.Addr) bool {
+	return ipAddress.IsMulticast()
+}
+
+// IPv4AddressClass returns the classful network class, "A" to "E", that the
+// first octet of an IPv4 address falls in.
+func IPv4AddressClass(ipAddress netip.Addr) (string, error) {
+	if !ipAddress.Is4() {
+		return "", fmt.Errorf("IPv4AddressClass: %w", ErrNotIPv4)
+	}
+
+	firstOctet := ipAddress.As4()[0]
+
+	switch {
+	case firstOctet < 128:
+		return "A", nil
+	case firstOctet < 192:
+		return "B", nil
+	case firstOctet < 224:
+		return "C", nil
+	case firstOctet < 240:
+		return "D", nil
+	default:
+		return "E", nil
+	}
+}
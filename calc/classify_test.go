@@ -0,0 +1,74 @@
+package calc
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestClassification(t *testing.T) {
+	tests := []struct {
+		input            string
+		private          bool
+		loopback         bool
+		linkLocalUnicast bool
+		multicast        bool
+	}{
+		{"10.1.2.3", true, false, false, false},
+		{"172.16.0.1", true, false, false, false},
+		{"192.168.1.1", true, false, false, false},
+		{"127.0.0.1", false, true, false, false},
+		{"169.254.1.1", false, false, true, false},
+		{"224.0.0.5", false, false, false, true},
+		{"8.8.8.8", false, false, false, false},
+		{"fd00::1", true, false, false, false},
+		{"::1", false, true, false, false},
+		{"fe80::1", false, false, true, false},
+		{"ff02::1", false, false, false, true},
+	}
+
+	for _, test := range tests {
+		ipAddr := netip.MustParseAddr(test.input)
+
+		if got := IsPrivateIP(ipAddr); got != test.private {
+			t.Errorf("IsPrivateIP(%s) = %t, want %t", test.input, got, test.private)
+		}
+
+		if got := IsLoopbackIP(ipAddr); got != test.loopback {
+			t.Errorf("IsLoopbackIP(%s) = %t, want %t", test.input, got, test.loopback)
+		}
+
+		if got := IsLinkLocalUnicastIP(ipAddr); got != test.linkLocalUnicast {
+			t.Errorf("IsLinkLocalUnicastIP(%s) = %t, want %t", test.input, got, test.linkLocalUnicast)
+		}
+
+		if got := IsMulticastIP(ipAddr); got != test.multicast {
+			t.Errorf("IsMulticastIP(%s) = %t, want %t", test.input, got, test.multicast)
+		}
+	}
+}
+
+func TestIPv4AddressClass(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		{"10.0.0.1", "A", nil},
+		{"127.255.255.255", "A", nil},
+		{"128.0.0.0", "B", nil},
+		{"191.255.0.1", "B", nil},
+		{"192.168.1.1", "C", nil},
+		{"224.0.0.5", "D", nil},
+		{"240.0.0.1", "E", nil},
+		{"255.255.255.255", "E", nil},
+		{"2001:db8::1", "", ErrNotIPv4},
+	}
+
+	for _, test := range tests {
+		got, err := IPv4AddressClass(netip.MustParseAddr(test.input))
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("IPv4AddressClass(%s) = %q, %v, want %q, %v", test.input, got, err, test.want, test.err)
+		}
+	}
+}
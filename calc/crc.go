@@ -0,0 +1,216 @@
+package calc
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const MaxTracedCRCBits = 64
+
+type CRCParameters struct {
+	Name       string
+	Width      int
+	Polynomial uint64
+	Init       uint64
+	ReflectIn  bool
+	ReflectOut bool
+	XorOut     uint64
+}
+
+var CRCPresets = []CRCParameters{
+	{Name: "CRC-8", Width: 8, Polynomial: 0x07},
+	{Name: "CRC-16/CCITT", Width: 16, Polynomial: 0x1021, Init: 0xFFFF},
+	{Name: "CRC-16/IBM", Width: 16, Polynomial: 0x8005, ReflectIn: true, ReflectOut: true},
+	{Name: "CRC-32/Ethernet", Width: 32, Polynomial: 0x04C11DB7, Init: 0xFFFFFFFF, ReflectIn: true, ReflectOut: true, XorOut: 0xFFFFFFFF},
+	{Name: "CRC-32C", Width: 32, Polynomial: 0x1EDC6F41, Init: 0xFFFFFFFF, ReflectIn: true, ReflectOut: true, XorOut: 0xFFFFFFFF},
+}
+
+func FindCRCPreset(name string) (CRCParameters, error) {
+	for _, preset := range CRCPresets {
+		if strings.EqualFold(preset.Name, name) {
+			return preset, nil
+		}
+	}
+
+	return CRCParameters{}, fmt.Errorf("FindCRCPreset: %q: %w", name, ErrUnknownCRC)
+}
+
+// ParseCRCPolynomial accepts a generator polynomial either in hex with the
+// implicit top bit omitted, where the number of digits sets the width (e.g.
+// "0x07" for CRC-8), or as a full binary divisor including the top bit (e.g.
+// "100000111"), and returns the width and the polynomial without its top bit.
+func ParseCRCPolynomial(polynomial string) (int, uint64, error) {
+	trimmedPolynomial := strings.ReplaceAll(polynomial, " ", "")
+
+	switch {
+	case strings.HasPrefix(strings.ToLower(trimmedPolynomial), "0x"):
+		value, err := strconv.ParseUint(trimmedPolynomial[2:], 16, 64)
+		if err != nil || value == 0 || len(trimmedPolynomial[2:]) > 16 {
+			return 0, 0, fmt.Errorf("ParseCRCPolynomial: %w", ErrInvalidPolynomial)
+		}
+
+		return len(trimmedPolynomial[2:]) * 4, value, nil
+	case trimmedPolynomial != "" && strings.Trim(trimmedPolynomial, "01") == "":
+		trimmedPolynomial = strings.TrimLeft(trimmedPolynomial, "0")
+		if len(trimmedPolynomial) < 2 || len(trimmedPolynomial) > 65 {
+			return 0, 0, fmt.Errorf("ParseCRCPolynomial: binary polynomial must have between 2 and 65 bits: %w", ErrInvalidPolynomial)
+		}
+
+		value, err := strconv.ParseUint(trimmedPolynomial[1:], 2, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("ParseCRCPolynomial: %w", ErrInvalidPolynomial)
+		}
+
+		return len(trimmedPolynomial) - 1, value, nil
+	default:
+		return 0, 0, fmt.Errorf("ParseCRCPolynomial: polynomial must be hex with a 0x prefix or binary: %w", ErrInvalidPolynomial)
+	}
+}
+
+func ComputeCRC(params CRCParameters, data []byte) (uint64, error) {
+	if params.Width < 1 || params.Width > 64 {
+		return 0, fmt.Errorf("ComputeCRC: %w", ErrInvalidCRCWidth)
+	}
+
+	mask := crcMask(params.Width)
+	topBit := uint64(1) << (params.Width - 1)
+	crc := params.Init & mask
+
+	for _, b := range data {
+		if params.ReflectIn {
+			b = bits.Reverse8(b)
+		}
+
+		for i := 7; i >= 0; i-- {
+			inBit := uint64(b>>i) & 1
+			feedback := (crc&topBit != 0) != (inBit == 1)
+			crc = (crc << 1) & mask
+
+			if feedback {
+				crc ^= params.Polynomial & mask
+			}
+		}
+	}
+
+	if params.ReflectOut {
+		crc = bits.Reverse64(crc) >> (64 - params.Width)
+	}
+
+	return (crc ^ params.XorOut) & mask, nil
+}
+
+func FormatCRC(params CRCParameters, crc uint64) string {
+	return fmt.Sprintf("%0*X", (params.Width+3)/4, crc)
+}
+
+// CRCDivisionSteps renders the textbook polynomial long division of the
+// message bits (with width zero bits appended) by the generator. The trace
+// shows the pure division, so init, reflection and xor-out are only applied
+// to the input and the remainder around it and listed as separate steps.
+func CRCDivisionSteps(params CRCParameters, data []byte) ([]string, error) {
+	if params.Width < 1 || params.Width > 64 {
+		return nil, fmt.Errorf("CRCDivisionSteps: %w", ErrInvalidCRCWidth)
+	}
+
+	if len(data)*8 > MaxTracedCRCBits {
+		return nil, fmt.Errorf("CRCDivisionSteps: only inputs up to %d bits can be traced: %w", MaxTracedCRCBits, ErrTraceTooLong)
+	}
+
+	var steps []string
+	var message strings.Builder
+
+	for _, b := range data {
+		if params.ReflectIn {
+			b = bits.Reverse8(b)
+		}
+
+		message.WriteString(fmt.Sprintf("%08b", b))
+	}
+
+	if params.ReflectIn {
+		steps = append(steps, "Reflect each input byte (least significant bit first).")
+	}
+
+	messageBits := message.String()
+	generator := "1" + fmt.Sprintf("%0*b", params.Width, params.Polynomial&crcMask(params.Width))
+	dividend := []byte(messageBits + strings.Repeat("0", params.Width))
+
+	if init := params.Init & crcMask(params.Width); init != 0 {
+		initBits := fmt.Sprintf("%0*b", params.Width, init)
+
+		for i := 0; i < params.Width; i++ {
+			if initBits[i] == '1' {
+				dividend[i] ^= 1
+			}
+		}
+
+		steps = append(steps, fmt.Sprintf("XOR the first %d bits with the init value %s.", params.Width, FormatBinInNimbles(initBits)))
+	}
+
+	steps = append(steps,
+		fmt.Sprintf("Generator: %s", FormatBinInNimbles(generator)),
+		fmt.Sprintf("Dividend:  %s (message followed by %d zero bits)", FormatBinInNimbles(string(dividend)), params.Width),
+	)
+
+	for i := 0; i < len(messageBits); i++ {
+		if dividend[i] != '1' {
+			continue
+		}
+
+		before := string(dividend)
+
+		for j := 0; j < len(generator); j++ {
+			if generator[j] == '1' {
+				dividend[i+j] ^= 1
+			}
+		}
+
+		divisorLine := strings.Repeat(" ", i) + generator + strings.Repeat(" ", len(dividend)-i-len(generator))
+
+		steps = append(steps,
+			fmt.Sprintf("  %s", groupTraceInNimbles(before)),
+			fmt.Sprintf("^ %s", groupTraceInNimbles(divisorLine)),
+			fmt.Sprintf("= %s", groupTraceInNimbles(string(dividend))),
+		)
+	}
+
+	remainder := string(dividend[len(messageBits):])
+	steps = append(steps, fmt.Sprintf("Remainder: %s", FormatBinInNimbles(remainder)))
+
+	if params.ReflectOut {
+		steps = append(steps, "Reflect the remainder.")
+	}
+
+	if params.XorOut != 0 {
+		steps = append(steps, fmt.Sprintf("XOR the remainder with %s.", FormatCRC(params, params.XorOut&crcMask(params.Width))))
+	}
+
+	return steps, nil
+}
+
+// groupTraceInNimbles groups a division trace line the same way as
+// FormatBinInNimbles, but keeps the padding spaces that align the generator
+// under the dividend.
+func groupTraceInNimbles(line string) string {
+	var buf strings.Builder
+
+	for i, c := range line {
+		if i != 0 && (len(line)-i)%4 == 0 {
+			buf.WriteByte(' ')
+		}
+
+		buf.WriteRune(c)
+	}
+
+	return buf.String()
+}
+
+func crcMask(width int) uint64 {
+	if width >= 64 {
+		return ^uint64(0)
+	}
+
+	return (uint64(1) << width) - 1
+}
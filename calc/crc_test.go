@@ -0,0 +1,141 @@
+package calc
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestComputeCRCCheckValues(t *testing.T) {
+	want := map[string]string{
+		"CRC-8":           "F4",
+		"CRC-16/CCITT":    "29B1",
+		"CRC-16/IBM":      "BB3D",
+		"CRC-32/Ethernet": "CBF43926",
+		"CRC-32C":         "E3069283",
+	}
+
+	for _, preset := range CRCPresets {
+		crc, err := ComputeCRC(preset, []byte("123456789"))
+		if err != nil {
+			t.Fatalf("ComputeCRC(%s) error = %v", preset.Name, err)
+		}
+
+		if got := FormatCRC(preset, crc); got != want[preset.Name] {
+			t.Errorf("ComputeCRC(%s, \"123456789\") = %s, want %s", preset.Name, got, want[preset.Name])
+		}
+	}
+
+	if _, err := ComputeCRC(CRCParameters{Width: 0}, nil); !errors.Is(err, ErrInvalidCRCWidth) {
+		t.Errorf("ComputeCRC with width 0 error = %v, want %v", err, ErrInvalidCRCWidth)
+	}
+}
+
+func TestFindCRCPreset(t *testing.T) {
+	if preset, err := FindCRCPreset("crc-32c"); err != nil || preset.Polynomial != 0x1EDC6F41 {
+		t.Errorf("FindCRCPreset(crc-32c) = %+v, %v", preset, err)
+	}
+
+	if _, err := FindCRCPreset("CRC-7"); !errors.Is(err, ErrUnknownCRC) {
+		t.Errorf("FindCRCPreset(CRC-7) error = %v, want %v", err, ErrUnknownCRC)
+	}
+}
+
+func TestParseCRCPolynomial(t *testing.T) {
+	tests := []struct {
+		input      string
+		width      int
+		polynomial uint64
+		err        error
+	}{
+		{"0x07", 8, 0x07, nil},
+		{"0x1021", 16, 0x1021, nil},
+		{"0x04C11DB7", 32, 0x04C11DB7, nil},
+		{"100000111", 8, 0x07, nil},
+		{"1011", 3, 0x3, nil},
+		{"0x", 0, 0, ErrInvalidPolynomial},
+		{"0xZZ", 0, 0, ErrInvalidPolynomial},
+		{"1", 0, 0, ErrInvalidPolynomial},
+		{"1021", 0, 0, ErrInvalidPolynomial},
+		{"0x00000000000000107", 0, 0, ErrInvalidPolynomial},
+	}
+
+	for _, test := range tests {
+		width, polynomial, err := ParseCRCPolynomial(test.input)
+		if !errors.Is(err, test.err) || width != test.width || polynomial != test.polynomial {
+			t.Errorf("ParseCRCPolynomial(%q) = %d, %#x, %v, want %d, %#x, %v", test.input, width, polynomial, err,
+				test.width, test.polynomial, test.err)
+		}
+	}
+}
+
+func TestCRCDivisionSteps(t *testing.T) {
+	steps, err := CRCDivisionSteps(CRCParameters{Width: 3, Polynomial: 0x3}, []byte{0xD3})
+	if err != nil {
+		t.Fatalf("CRCDivisionSteps error = %v", err)
+	}
+
+	if got := steps[len(steps)-1]; got != "Remainder: 011" {
+		t.Errorf("last step = %q, want %q", got, "Remainder: 011")
+	}
+
+	if _, err := CRCDivisionSteps(CRCPresets[0], make([]byte, MaxTracedCRCBits/8+1)); !errors.Is(err, ErrTraceTooLong) {
+		t.Errorf("CRCDivisionSteps with a long input error = %v, want %v", err, ErrTraceTooLong)
+	}
+}
+
+// TestCRCDivisionStepsMatchComputeCRC checks that the remainder of the
+// long-division trace, after reflect-out and xor-out, is the computed CRC.
+func TestCRCDivisionStepsMatchComputeCRC(t *testing.T) {
+	inputs := [][]byte{{0xA5}, {0xA5, 0x3C}, {1, 2, 3, 4, 5, 6, 7, 8}}
+
+	for _, preset := range CRCPresets {
+		for _, input := range inputs {
+			steps, err := CRCDivisionSteps(preset, input)
+			if err != nil {
+				t.Fatalf("CRCDivisionSteps(%s, %X) error = %v", preset.Name, input, err)
+			}
+
+			var remainder uint64
+
+			for _, step := range steps {
+				if strings.HasPrefix(step, "Remainder: ") {
+					remainder, _ = strconv.ParseUint(strings.ReplaceAll(step[len("Remainder: "):], " ", ""), 2, 64)
+				}
+			}
+
+			if preset.ReflectOut {
+				remainder = bits.Reverse64(remainder) >> (64 - preset.Width)
+			}
+
+			remainder ^= preset.XorOut
+
+			if crc, _ := ComputeCRC(preset, input); crc != remainder {
+				t.Errorf("%s(%X): trace gives %X, ComputeCRC gives %X", preset.Name, input, remainder, crc)
+			}
+		}
+	}
+}
+
+func FuzzParseCRCPolynomial(f *testing.F) {
+	f.Add("0x07")
+	f.Add("100000111")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		width, polynomial, err := ParseCRCPolynomial(input)
+		if err != nil {
+			return
+		}
+
+		params := CRCParameters{Width: width, Polynomial: polynomial}
+		if width > 64 {
+			return
+		}
+
+		if _, err := ComputeCRC(params, []byte("123456789")); err != nil && !errors.Is(err, ErrInvalidCRCWidth) {
+			t.Errorf("ComputeCRC(%+v) error = %v", params, err)
+		}
+	})
+}
@@ -0,0 +1,11 @@
+// Package calc implements the network calculations behind netcalc: IPv4 and
+// IPv6 address conversions, masks and network addresses, number base
+// conversions, subnetting, VLSM planning, lookups in the IANA special-purpose
+// address registries, and the Internet checksum and CRC algorithms.
+//
+// Addresses and prefixes are passed as netip.Addr and netip.Prefix values.
+// Text formats that have no standard library type, such as an address in
+// hexadecimal or binary, are parsed and formatted by the functions in this
+// package. Errors wrap one of the exported Err values, so callers can test
+// for them with errors.Is.
+package calc
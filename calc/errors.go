@@ -0,0 +1,36 @@
+package calc
+
+import "errors"
+
+var (
+	ErrInvalidAddress      = errors.New("IP address is invalid")
+	ErrNotIPv4             = errors.New("IP address is not IPv4")
+	ErrNotIPv6             = errors.New("IP address is not IPv6")
+	ErrFamilyMismatch      = errors.New("IP addresses are not of the same family")
+	ErrInvalidHex          = errors.New("hexadecimal number is invalid")
+	ErrInvalidBin          = errors.New("binary number is invalid")
+	ErrInvalidDec          = errors.New("decimal number is invalid")
+	ErrInvalidOct          = errors.New("octal number is invalid")
+	ErrInvalidNumber       = errors.New("number is invalid in the given radix")
+	ErrInvalidRadix        = errors.New("radix must be between 2 and 36")
+	ErrInvalidPrefixLength = errors.New("prefix length is invalid")
+	ErrInvalidMask         = errors.New("network mask is invalid")
+	ErrInvalidCount        = errors.New("count must be at least 1")
+	ErrTooManySubnets      = errors.New("too many subnets to list")
+	ErrDoesNotFit          = errors.New("requirements do not fit in the network")
+	ErrInvalidRequirement  = errors.New("requirement is invalid")
+	ErrEmptyInput          = errors.New("input is empty")
+	ErrInvalidPolynomial   = errors.New("CRC polynomial is invalid")
+	ErrInvalidCRCWidth     = errors.New("CRC width must be between 1 and 64")
+	ErrUnknownCRC          = errors.New("CRC is not a known preset")
+	ErrTraceTooLong        = errors.New("input is too long to trace")
+	ErrUnknownOperator     = errors.New("operator is not known")
+	ErrInvalidWidth        = errors.New("bit width is not supported")
+	ErrOutOfRange          = errors.New("value is out of range")
+	ErrUnknownCategory     = errors.New("category is not known")
+	ErrUnknownFormat       = errors.New("output format is not known")
+	ErrInvalidRange        = errors.New("address range is invalid")
+	ErrUnknownAction       = errors.New("ACL action must be permit or deny")
+	ErrInvalidACLName      = errors.New("ACL name is invalid")
+	ErrInvalidMAC          = errors.New("MAC address is invalid")
+)
@@ -0,0 +1,105 @@
+package calc_test
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/KhangBBBB/netcalc/calc"
+)
+
+func ExampleIPv4ToHexFormat() {
+	ipv4, _ := calc.ParseIPv4("192.168.1.1")
+	hexValue, _ := calc.IPv4ToHexFormat(ipv4)
+	fmt.Println(hexValue)
+	// Output: C0A80101
+}
+
+func ExampleBinToIPv4Format() {
+	ipv4, _ := calc.BinToIPv4Format("1100 0000 1010 1000 0000 0001 0000 0001")
+	fmt.Println(ipv4)
+	// Output: 192.168.1.1
+}
+
+func ExampleIPv6ToHexFormat() {
+	ipv6, _ := calc.ParseIPv6("2001:db8::1")
+	hexValue, _ := calc.IPv6ToHexFormat(ipv6)
+	fmt.Println(hexValue)
+	// Output: 2001:0DB8:0000:0000:0000:0000:0000:0001
+}
+
+func ExampleFindNetworkAddress() {
+	hostIP := netip.MustParseAddr("192.168.1.77")
+	netMask := netip.MustParseAddr("255.255.255.0")
+	network, _ := calc.FindNetworkAddress(hostIP, netMask)
+	fmt.Println(network)
+	// Output: 192.168.1.0/24
+}
+
+func ExampleFindNetworkAddress_invalidMask() {
+	hostIP := netip.MustParseAddr("192.168.1.77")
+	netMask := netip.MustParseAddr("255.0.255.0")
+	_, err := calc.FindNetworkAddress(hostIP, netMask)
+	fmt.Println(errors.Is(err, calc.ErrInvalidMask))
+	// Output: true
+}
+
+func ExampleCIDRSlashValueToNetworkMask() {
+	ones, _ := calc.ParseCIDRSlashValue("/20")
+	netMask, _ := calc.CIDRSlashValueToNetworkMask(ones)
+	fmt.Println(netMask)
+	// Output: 255.255.240.0
+}
+
+func ExampleIsPrivateIP() {
+	fmt.Println(calc.IsPrivateIP(netip.MustParseAddr("10.1.2.3")))
+	fmt.Println(calc.IsPrivateIP(netip.MustParseAddr("8.8.8.8")))
+	// Output:
+	// true
+	// false
+}
+
+func ExampleFormatBinInNimbles() {
+	fmt.Println(calc.FormatBinInNimbles("1011111"))
+	// Output: 101 1111
+}
+
+func ExampleSplitNetworkBySubnetCount() {
+	subnets, _, _ := calc.SplitNetworkBySubnetCount(netip.MustParsePrefix("192.168.1.0/24"), 4)
+	for _, subnet := range subnets {
+		fmt.Println(subnet.Prefix, subnet.FirstUsableHost, subnet.LastUsableHost, subnet.Broadcast)
+	}
+	// Output:
+	// 192.168.1.0/26 192.168.1.1 192.168.1.62 192.168.1.63
+	// 192.168.1.64/26 192.168.1.65 192.168.1.126 192.168.1.127
+	// 192.168.1.128/26 192.168.1.129 192.168.1.190 192.168.1.191
+	// 192.168.1.192/26 192.168.1.193 192.168.1.254 192.168.1.255
+}
+
+func ExamplePlanVLSM() {
+	requirements, _ := calc.ParseVLSMRequirements("Sales 100, Engineering 50, WAN link 2")
+	plan, _, _ := calc.PlanVLSM(netip.MustParsePrefix("10.0.0.0/24"), requirements)
+	for _, allocation := range plan.Allocations {
+		fmt.Println(allocation.Name, allocation.Prefix, allocation.WastedHosts)
+	}
+	fmt.Println(plan.FreeBlocks)
+	// Output:
+	// Sales 10.0.0.0/25 26
+	// Engineering 10.0.0.128/26 12
+	// WAN link 10.0.0.192/31 0
+	// [10.0.0.194/31 10.0.0.196/30 10.0.0.200/29 10.0.0.208/28 10.0.0.224/27]
+}
+
+func ExampleInternetChecksum() {
+	data, _ := calc.ParseHexBytes("4500 0073 0000 4000 4011 0000 c0a8 0001 c0a8 00c7")
+	checksum, _ := calc.InternetChecksum(data)
+	fmt.Printf("%04X\n", checksum)
+	// Output: B861
+}
+
+func ExampleComputeCRC() {
+	params, _ := calc.FindCRCPreset("CRC-32/Ethernet")
+	crc, _ := calc.ComputeCRC(params, []byte("123456789"))
+	fmt.Println(calc.FormatCRC(params, crc))
+	// Output: CBF43926
+}
@@ -0,0 +1,116 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+)
+
+// RangeToCIDRs splits the inclusive address range [start, end] into the
+// fewest CIDR prefixes. Each step explains why the next prefix is as large as
+// it is: a block of 2^n addresses must start on a multiple of 2^n and must
+// not run past the end of the range.
+func RangeToCIDRs(start netip.Addr, end netip.Addr) ([]netip.Prefix, []string, error) {
+	if !start.IsValid() || !end.IsValid() {
+		return nil, nil, fmt.Errorf("RangeToCIDRs: %w", ErrInvalidAddress)
+	}
+
+	if start.BitLen() != end.BitLen() {
+		return nil, nil, fmt.Errorf("RangeToCIDRs: %w", ErrFamilyMismatch)
+	}
+
+	if end.Less(start) {
+		return nil, nil, fmt.Errorf("RangeToCIDRs: %s is after %s: %w", start, end, ErrInvalidRange)
+	}
+
+	bits := start.BitLen()
+	cursor := addrToBig(start)
+	last := addrToBig(end)
+
+	var prefixes []netip.Prefix
+	var steps []string
+
+	for cursor.Cmp(last) <= 0 {
+		alignBits := int(cursor.TrailingZeroBits())
+		if cursor.Sign() == 0 || alignBits > bits {
+			alignBits = bits
+		}
+
+		remaining := new(big.Int).Sub(last, cursor)
+		remaining.Add(remaining, big.NewInt(1))
+		fitBits := remaining.BitLen() - 1
+
+		hostBits := alignBits
+		if fitBits < hostBits {
+			hostBits = fitBits
+		}
+
+		prefix := netip.PrefixFrom(bigToAddr(cursor, bits), bits-hostBits)
+		prefixes = append(prefixes, prefix)
+
+		steps = append(steps, fmt.Sprintf("From %s: alignment allows up to 2^%d addresses (/%d) and %s addresses remain, which allows up to 2^%d (/%d), so take %s.",
+			prefix.Addr(), alignBits, bits-alignBits, remaining, fitBits, bits-fitBits, prefix))
+
+		cursor.Add(cursor, new(big.Int).Lsh(big.NewInt(1), uint(hostBits)))
+	}
+
+	return prefixes, steps, nil
+}
+
+// PrefixRange returns the first and the last address of prefix, ignoring
+// any host bits set in its address.
+func PrefixRange(prefix netip.Prefix) (netip.Addr, netip.Addr, []string, error) {
+	if !prefix.IsValid() {
+		return netip.Addr{}, netip.Addr{}, nil, fmt.Errorf("PrefixRange: %w", ErrInvalidAddress)
+	}
+
+	bits := prefix.Addr().BitLen()
+	hostBits := bits - prefix.Bits()
+	first := prefix.Masked().Addr()
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+	last := bigToAddr(new(big.Int).Add(addrToBig(first), new(big.Int).Sub(size, big.NewInt(1))), bits)
+
+	steps := []string{
+		fmt.Sprintf("/%d leaves %d - %d = %d host bits, so the prefix has 2^%d = %s addresses.", prefix.Bits(), bits, prefix.Bits(), hostBits, hostBits, size),
+		fmt.Sprintf("Setting the host bits to 0 gives the first address %s.", first),
+		fmt.Sprintf("Setting the host bits to 1 gives the last address %s.", last),
+	}
+
+	return first, last, steps, nil
+}
+
+// ParseAddrRange parses an inclusive range written as "10.0.0.5-10.0.1.200",
+// with an optional en dash and spaces, as copied from asset lists.
+func ParseAddrRange(addrRange string) (netip.Addr, netip.Addr, error) {
+	normalized := strings.NewReplacer("–", "-", "—", "-").Replace(addrRange)
+
+	startText, endText, ok := strings.Cut(normalized, "-")
+	if !ok {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("ParseAddrRange: %w", ErrInvalidRange)
+	}
+
+	start, err := netip.ParseAddr(strings.TrimSpace(startText))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("ParseAddrRange: %w", ErrInvalidAddress)
+	}
+
+	end, err := netip.ParseAddr(strings.TrimSpace(endText))
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("ParseAddrRange: %w", ErrInvalidAddress)
+	}
+
+	return start, end, nil
+}
+
+func addrToBig(ipAddress netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(ipAddress.AsSlice())
+}
+
+func bigToAddr(value *big.Int, bits int) netip.Addr {
+	octets := value.FillBytes(make([]byte, bits/8))
+	ipAddress, _ := netip.AddrFromSlice(octets)
+
+	return ipAddress
+}
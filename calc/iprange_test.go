@@ -0,0 +1,165 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"testing"
+	"testing/quick"
+)
+
+func TestRangeToCIDRs(t *testing.T) {
+	tests := []struct {
+		start string
+		end   string
+		want  string
+	}{
+		{"10.0.0.5", "10.0.1.200", "[10.0.0.5/32 10.0.0.6/31 10.0.0.8/29 10.0.0.16/28 10.0.0.32/27 10.0.0.64/26 10.0.0.128/25 10.0.1.0/25 10.0.1.128/26 10.0.1.192/29 10.0.1.200/32]"},
+		{"192.168.0.0", "192.168.0.255", "[192.168.0.0/24]"},
+		{"192.168.0.1", "192.168.0.1", "[192.168.0.1/32]"},
+		{"0.0.0.0", "255.255.255.255", "[0.0.0.0/0]"},
+		{"255.255.255.254", "255.255.255.255", "[255.255.255.254/31]"},
+		{"2001:db8::1", "2001:db8::8", "[2001:db8::1/128 2001:db8::2/127 2001:db8::4/126 2001:db8::8/128]"},
+		{"::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "[::/0]"},
+		{"2001:db8::", "2001:db8:0:ffff:ffff:ffff:ffff:ffff", "[2001:db8::/48]"},
+	}
+
+	for _, test := range tests {
+		got, steps, err := RangeToCIDRs(netip.MustParseAddr(test.start), netip.MustParseAddr(test.end))
+		if err != nil || fmt.Sprint(got) != test.want || len(steps) != len(got) {
+			t.Errorf("RangeToCIDRs(%s, %s) = %v, %d steps, %v, want %s", test.start, test.end, got, len(steps), err, test.want)
+		}
+	}
+
+	invalid := []struct {
+		start string
+		end   string
+		err   error
+	}{
+		{"10.0.0.2", "10.0.0.1", ErrInvalidRange},
+		{"10.0.0.1", "2001:db8::1", ErrFamilyMismatch},
+	}
+
+	for _, test := range invalid {
+		if _, _, err := RangeToCIDRs(netip.MustParseAddr(test.start), netip.MustParseAddr(test.end)); !errors.Is(err, test.err) {
+			t.Errorf("RangeToCIDRs(%s, %s) error = %v, want %v", test.start, test.end, err, test.err)
+		}
+	}
+}
+
+func TestRangeToCIDRsCoversRange(t *testing.T) {
+	covers := func(a uint32, b uint32) bool {
+		if a > b {
+			a, b = b, a
+		}
+
+		prefixes, _, err := RangeToCIDRs(uint32ToIPv4(a), uint32ToIPv4(b))
+		if err != nil {
+			return false
+		}
+
+		next := uint64(a)
+
+		for _, prefix := range prefixes {
+			first, last, _, _ := PrefixRange(prefix)
+			if uint64(ipv4ToUint32(first)) != next || prefix != prefix.Masked() {
+				return false
+			}
+
+			next = uint64(ipv4ToUint32(last)) + 1
+		}
+
+		return next == uint64(b)+1 && len(prefixes) <= 62
+	}
+
+	if err := quick.Check(covers, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrefixRange(t *testing.T) {
+	tests := []struct {
+		prefix string
+		first  string
+		last   string
+	}{
+		{"10.0.0.0/22", "10.0.0.0", "10.0.3.255"},
+		{"192.168.1.77/24", "192.168.1.0", "192.168.1.255"},
+		{"10.0.0.1/32", "10.0.0.1", "10.0.0.1"},
+		{"0.0.0.0/0", "0.0.0.0", "255.255.255.255"},
+		{"2001:db8::/32", "2001:db8::", "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"::/0", "::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+	}
+
+	for _, test := range tests {
+		first, last, steps, err := PrefixRange(netip.MustParsePrefix(test.prefix))
+		if err != nil || first.String() != test.first || last.String() != test.last || len(steps) == 0 {
+			t.Errorf("PrefixRange(%s) = %s, %s, %v, want %s, %s", test.prefix, first, last, err, test.first, test.last)
+		}
+	}
+
+	if _, _, _, err := PrefixRange(netip.Prefix{}); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("PrefixRange(invalid) error = %v, want %v", err, ErrInvalidAddress)
+	}
+}
+
+func TestParseAddrRange(t *testing.T) {
+	tests := []struct {
+		input string
+		start string
+		end   string
+		err   error
+	}{
+		{"10.0.0.5-10.0.1.200", "10.0.0.5", "10.0.1.200", nil},
+		{"10.0.0.5 – 10.0.1.200", "10.0.0.5", "10.0.1.200", nil},
+		{" 2001:db8::1 - 2001:db8::ff ", "2001:db8::1", "2001:db8::ff", nil},
+		{"10.0.0.5", "", "", ErrInvalidRange},
+		{"10.0.0.5-10.0.1", "", "", ErrInvalidAddress},
+	}
+
+	for _, test := range tests {
+		start, end, err := ParseAddrRange(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseAddrRange(%q) error = %v, want %v", test.input, err, test.err)
+			continue
+		}
+
+		if err == nil && (start.String() != test.start || end.String() != test.end) {
+			t.Errorf("ParseAddrRange(%q) = %s, %s, want %s, %s", test.input, start, end, test.start, test.end)
+		}
+	}
+}
+
+func TestAddrBigRoundTrip(t *testing.T) {
+	roundTrip := func(octets [16]byte) bool {
+		ipv6 := netip.AddrFrom16(octets)
+
+		return bigToAddr(addrToBig(ipv6), 128) == ipv6 && addrToBig(ipv6).Cmp(new(big.Int).SetBytes(octets[:])) == 0
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzParseAddrRange(f *testing.F) {
+	f.Add("10.0.0.5-10.0.1.200")
+	f.Add("10.0.0.5 – 10.0.1.200")
+	f.Add("2001:db8::1 - 2001:db8::ff")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		start, end, err := ParseAddrRange(input)
+		if err != nil {
+			return
+		}
+
+		if !start.IsValid() || !end.IsValid() {
+			t.Fatalf("ParseAddrRange(%q) = %s, %s without an error", input, start, end)
+		}
+
+		if againStart, againEnd, err := ParseAddrRange(start.String() + "-" + end.String()); err != nil || againStart != start || againEnd != end {
+			t.Errorf("ParseAddrRange(%q) = %s, %s, which does not round trip: %s, %s, %v", input, start, end, againStart, againEnd, err)
+		}
+	})
+}
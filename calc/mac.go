@@ -0,0 +1,90 @@
+package calc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// MACAddress is an EUI-48 hardware address.
+type MACAddress [6]byte
+
+// ParseMAC accepts the colon (00:1a:2b:3c:4d:5e), hyphen (00-1A-2B-3C-4D-5E)
+// and Cisco dotted (001a.2b3c.4d5e) notations, and bare hex digits.
+func ParseMAC(macAddress string) (MACAddress, error) {
+	trimmedValue := strings.TrimSpace(macAddress)
+
+	var digits string
+
+	switch {
+	case len(trimmedValue) == 17 && (strings.Count(trimmedValue, ":") == 5 || strings.Count(trimmedValue, "-") == 5):
+		separator := trimmedValue[2:3]
+
+		for i := 2; i < len(trimmedValue); i += 3 {
+			if trimmedValue[i:i+1] != separator {
+				return MACAddress{}, fmt.Errorf("ParseMAC: %w", ErrInvalidMAC)
+			}
+		}
+
+		digits = strings.ReplaceAll(trimmedValue, separator, "")
+	case len(trimmedValue) == 14 && trimmedValue[4] == '.' && trimmedValue[9] == '.':
+		digits = strings.ReplaceAll(trimmedValue, ".", "")
+	case len(trimmedValue) == 12:
+		digits = trimmedValue
+	default:
+		return MACAddress{}, fmt.Errorf("ParseMAC: %w", ErrInvalidMAC)
+	}
+
+	var mac MACAddress
+
+	if len(digits) != 12 {
+		return MACAddress{}, fmt.Errorf("ParseMAC: %w", ErrInvalidMAC)
+	}
+
+	if _, err := hex.Decode(mac[:], []byte(digits)); err != nil {
+		return MACAddress{}, fmt.Errorf("ParseMAC: %w", ErrInvalidMAC)
+	}
+
+	return mac, nil
+}
+
+func (mac MACAddress) String() string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", mac[0], mac[1], mac[2], mac[3], mac[4], mac[5])
+}
+
+func (mac MACAddress) Hyphenated() string {
+	return fmt.Sprintf("%02X-%02X-%02X-%02X-%02X-%02X", mac[0], mac[1], mac[2], mac[3], mac[4], mac[5])
+}
+
+func (mac MACAddress) CiscoFormat() string {
+	return fmt.Sprintf("%02x%02x.%02x%02x.%02x%02x", mac[0], mac[1], mac[2], mac[3], mac[4], mac[5])
+}
+
+// OUI returns the organizationally unique identifier, the first three
+// octets, in the form the IEEE registry lists it.
+func (mac MACAddress) OUI() string {
+	return fmt.Sprintf("%02X-%02X-%02X", mac[0], mac[1], mac[2])
+}
+
+// IsMulticast reports whether the I/G bit, the least significant bit of the
+// first octet, is set.
+func (mac MACAddress) IsMulticast() bool {
+	return mac[0]&0x01 != 0
+}
+
+// IsLocallyAdministered reports whether the U/L bit, the second least
+// significant bit of the first octet, is set.
+func (mac MACAddress) IsLocallyAdministered() bool {
+	return mac[0]&0x02 != 0
+}
+
+// LinkLocalAddress returns the IPv6 link-local address with the modified
+// EUI-64 interface identifier of mac (RFC 4291, Appendix A): ff:fe is
+// inserted in the middle and the U/L bit is flipped.
+func (mac MACAddress) LinkLocalAddress() netip.Addr {
+	return netip.AddrFrom16([16]byte{
+		0xfe, 0x80, 0, 0, 0, 0, 0, 0,
+		mac[0] ^ 0x02, mac[1], mac[2], 0xff, 0xfe, mac[3], mac[4], mac[5],
+	})
+}
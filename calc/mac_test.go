@@ -0,0 +1,88 @@
+package calc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMAC(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{"00:1a:2b:3c:4d:5e", "00:1a:2b:3c:4d:5e", nil},
+		{"00-1A-2B-3C-4D-5E", "00:1a:2b:3c:4d:5e", nil},
+		{"001a.2b3c.4d5e", "00:1a:2b:3c:4d:5e", nil},
+		{" 001A2B3C4D5E ", "00:1a:2b:3c:4d:5e", nil},
+		{"00:1a-2b:3c:4d:5e", "", ErrInvalidMAC},
+		{"00:1a:2b:3c:4d", "", ErrInvalidMAC},
+		{"00:1a:2b:3c:4d:5g", "", ErrInvalidMAC},
+		{"001a2b3c4d5e6f", "", ErrInvalidMAC},
+		{"", "", ErrInvalidMAC},
+	}
+
+	for _, test := range tests {
+		got, err := ParseMAC(test.input)
+		if !errors.Is(err, test.wantErr) || (err == nil && got.String() != test.want) {
+			t.Errorf("ParseMAC(%q) = %s, %v, want %s, %v", test.input, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestMACAddressFormats(t *testing.T) {
+	mac, _ := ParseMAC("00:1a:2b:3c:4d:5e")
+
+	if got := mac.Hyphenated(); got != "00-1A-2B-3C-4D-5E" {
+		t.Errorf("Hyphenated() = %s", got)
+	}
+
+	if got := mac.CiscoFormat(); got != "001a.2b3c.4d5e" {
+		t.Errorf("CiscoFormat() = %s", got)
+	}
+
+	if got := mac.OUI(); got != "00-1A-2B" {
+		t.Errorf("OUI() = %s", got)
+	}
+
+	if got := mac.LinkLocalAddress().String(); got != "fe80::21a:2bff:fe3c:4d5e" {
+		t.Errorf("LinkLocalAddress() = %s", got)
+	}
+}
+
+func TestMACAddressFlags(t *testing.T) {
+	tests := []struct {
+		input     string
+		multicast bool
+		local     bool
+	}{
+		{"00:1a:2b:3c:4d:5e", false, false},
+		{"01:00:5e:00:00:01", true, false},
+		{"02:42:ac:11:00:02", false, true},
+		{"ff:ff:ff:ff:ff:ff", true, true},
+	}
+
+	for _, test := range tests {
+		mac, _ := ParseMAC(test.input)
+		if mac.IsMulticast() != test.multicast || mac.IsLocallyAdministered() != test.local {
+			t.Errorf("%s: multicast %t, local %t, want %t, %t", test.input, mac.IsMulticast(), mac.IsLocallyAdministered(), test.multicast, test.local)
+		}
+	}
+}
+
+func FuzzParseMAC(f *testing.F) {
+	for _, seed := range []string{"00:1a:2b:3c:4d:5e", "001a.2b3c.4d5e", "00-1A-2B-3C-4D-5E", "zz"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		mac, err := ParseMAC(input)
+		if err != nil {
+			return
+		}
+
+		if again, err := ParseMAC(mac.String()); err != nil || again != mac {
+			t.Errorf("ParseMAC(%q) = %s, which does not round-trip: %s, %v", input, mac, again, err)
+		}
+	})
+}
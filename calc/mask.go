@@ -0,0 +1,216 @@
+package calc
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// ParseCIDRSlashValue parses a prefix length written as "/24".
+func ParseCIDRSlashValue(cidrSlashValue string) (int, error) {
+	trimmedValue := strings.TrimSpace(cidrSlashValue)
+	if !strings.HasPrefix(trimmedValue, "/") {
+		return 0, fmt.Errorf("ParseCIDRSlashValue: %w", ErrInvalidPrefixLength)
+	}
+
+	ones, err := strconv.Atoi(trimmedValue[1:])
+	if err != nil || strings.ContainsAny(trimmedValue, "+-") || ones < 0 || ones > 128 {
+		return 0, fmt.Errorf("ParseCIDRSlashValue: %w", ErrInvalidPrefixLength)
+	}
+
+	return ones, nil
+}
+
+// ParsePrefixLength accepts a prefix length with or without the leading "/"
+// ("24", "/24") or a network mask ("255.255.255.0") of an address family with
+// the given number of bits.
+func ParsePrefixLength(prefixLength string, bits int) (int, error) {
+	trimmedValue := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(prefixLength), "/"))
+
+	if strings.ContainsAny(trimmedValue, ".:") {
+		netMask, err := netip.ParseAddr(trimmedValue)
+		if err != nil {
+			return 0, fmt.Errorf("ParsePrefixLength: %w", ErrInvalidMask)
+		}
+
+		if netMask.BitLen() != bits {
+			return 0, fmt.Errorf("ParsePrefixLength: %w", ErrFamilyMismatch)
+		}
+
+		ones, err := maskOnes(netMask)
+		if err != nil {
+			return 0, fmt.Errorf("ParsePrefixLength: %w", err)
+		}
+
+		return ones, nil
+	}
+
+	ones, err := strconv.Atoi(trimmedValue)
+	if err != nil || strings.ContainsAny(trimmedValue, "+-") || ones < 0 || ones > bits {
+		return 0, fmt.Errorf("ParsePrefixLength: %w", ErrInvalidPrefixLength)
+	}
+
+	return ones, nil
+}
+
+// ParseHostPrefix parses a host address together with its prefix length as
+// copied from router configurations: "192.168.1.77/24",
+// "192.168.1.77/255.255.255.0" or "192.168.1.77 255.255.255.0". Unlike
+// netip.ParsePrefix, the host bits of the address are kept.
+func ParseHostPrefix(hostPrefix string) (netip.Prefix, error) {
+	trimmedValue := strings.TrimSpace(hostPrefix)
+
+	separator := strings.IndexAny(trimmedValue, "/ \t")
+	if separator < 0 {
+		return netip.Prefix{}, fmt.Errorf("ParseHostPrefix: %w", ErrInvalidPrefixLength)
+	}
+
+	hostIPAddress, err := netip.ParseAddr(trimmedValue[:separator])
+	if err != nil || hostIPAddress.Zone() != "" {
+		return netip.Prefix{}, fmt.Errorf("ParseHostPrefix: %w", ErrInvalidAddress)
+	}
+
+	ones, err := ParsePrefixLength(trimmedValue[separator:], hostIPAddress.BitLen())
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("ParseHostPrefix: %w", err)
+	}
+
+	return netip.PrefixFrom(hostIPAddress, ones), nil
+}
+
+func FormatCIDRSlashValue(ones int) string {
+	return "/" + strconv.Itoa(ones)
+}
+
+func NetworkMaskToCIDRSlashValue(netMask netip.Addr) (int, error) {
+	if !netMask.Is4() {
+		return 0, fmt.Errorf("NetworkMaskToCIDRSlashValue: %w", ErrNotIPv4)
+	}
+
+	ones, err := maskOnes(netMask)
+	if err != nil {
+		return 0, fmt.Errorf("NetworkMaskToCIDRSlashValue: %w", err)
+	}
+
+	return ones, nil
+}
+
+func CIDRSlashValueToNetworkMask(ones int) (netip.Addr, error) {
+	if ones < 0 || ones > 32 {
+		return netip.Addr{}, fmt.Errorf("CIDRSlashValueToNetworkMask: %w", ErrInvalidPrefixLength)
+	}
+
+	return maskFromOnes(ones, 32), nil
+}
+
+func IPv6MaskToCIDRSlashValue(netMask netip.Addr) (int, error) {
+	if !netMask.Is6() {
+		return 0, fmt.Errorf("IPv6MaskToCIDRSlashValue: %w", ErrNotIPv6)
+	}
+
+	ones, err := maskOnes(netMask)
+	if err != nil {
+		return 0, fmt.Errorf("IPv6MaskToCIDRSlashValue: %w", err)
+	}
+
+	return ones, nil
+}
+
+func CIDRSlashValueToIPv6Mask(ones int) (netip.Addr, error) {
+	if ones < 0 || ones > 128 {
+		return netip.Addr{}, fmt.Errorf("CIDRSlashValueToIPv6Mask: %w", ErrInvalidPrefixLength)
+	}
+
+	return maskFromOnes(ones, 128), nil
+}
+
+// FindNetworkAddress works for both IPv4 and IPv6 as long as the host
+// address and the mask are of the same family.
+func FindNetworkAddress(hostIPAddress netip.Addr, networkMask netip.Addr) (netip.Prefix, error) {
+	if !hostIPAddress.IsValid() || !networkMask.IsValid() {
+		return netip.Prefix{}, fmt.Errorf("FindNetworkAddress: %w", ErrInvalidAddress)
+	}
+
+	if hostIPAddress.BitLen() != networkMask.BitLen() {
+		return netip.Prefix{}, fmt.Errorf("FindNetworkAddress: %w", ErrFamilyMismatch)
+	}
+
+	ones, err := maskOnes(networkMask)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("FindNetworkAddress: %w", err)
+	}
+
+	return hostIPAddress.Prefix(ones)
+}
+
+// NonContiguousMaskBit returns the position, counting from 0 at the most
+// significant bit, of the first 1 bit that follows a 0 bit in netMask. It
+// returns false if the mask is contiguous.
+func NonContiguousMaskBit(netMask netip.Addr) (int, bool) {
+	octets := netMask.AsSlice()
+	zeroSeen := false
+
+	for i := 0; i < len(octets)*8; i++ {
+		set := octets[i/8]&(0x80>>(i%8)) != 0
+
+		if set && zeroSeen {
+			return i, true
+		}
+
+		zeroSeen = zeroSeen || !set
+	}
+
+	return 0, false
+}
+
+// ApplyBitmask ANDs an address with an arbitrary bitmask. Unlike
+// FindNetworkAddress the mask does not have to be contiguous, as with the
+// masks some ACLs and hash-based load balancers use.
+func ApplyBitmask(ipAddress netip.Addr, bitmask netip.Addr) (netip.Addr, error) {
+	if !ipAddress.IsValid() || !bitmask.IsValid() {
+		return netip.Addr{}, fmt.Errorf("ApplyBitmask: %w", ErrInvalidAddress)
+	}
+
+	if ipAddress.BitLen() != bitmask.BitLen() {
+		return netip.Addr{}, fmt.Errorf("ApplyBitmask: %w", ErrFamilyMismatch)
+	}
+
+	octets := ipAddress.AsSlice()
+	maskOctets := bitmask.AsSlice()
+
+	for i := range octets {
+		octets[i] &= maskOctets[i]
+	}
+
+	result, _ := netip.AddrFromSlice(octets)
+
+	return result, nil
+}
+
+func maskOnes(netMask netip.Addr) (int, error) {
+	if bit, ok := NonContiguousMaskBit(netMask); ok {
+		return 0, fmt.Errorf("%s is not contiguous, bit %d is set after a 0 bit: %w", netMask, bit, ErrInvalidMask)
+	}
+
+	octets := netMask.AsSlice()
+	ones := 0
+
+	for ones < len(octets)*8 && octets[ones/8]&(0x80>>(ones%8)) != 0 {
+		ones++
+	}
+
+	return ones, nil
+}
+
+func maskFromOnes(ones int, bits int) netip.Addr {
+	octets := make([]byte, bits/8)
+
+	for i := 0; i < ones; i++ {
+		octets[i/8] |= 0x80 >> (i % 8)
+	}
+
+	mask, _ := netip.AddrFromSlice(octets)
+
+	return mask
+}
@@ -0,0 +1,371 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestParseCIDRSlashValue(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+		err   error
+	}{
+		{"/0", 0, nil},
+		{"/24", 24, nil},
+		{" /128 ", 128, nil},
+		{"24", 0, ErrInvalidPrefixLength},
+		{"/", 0, ErrInvalidPrefixLength},
+		{"/-1", 0, ErrInvalidPrefixLength},
+		{"/+24", 0, ErrInvalidPrefixLength},
+		{"/-0", 0, ErrInvalidPrefixLength},
+		{"/129", 0, ErrInvalidPrefixLength},
+		{"", 0, ErrInvalidPrefixLength},
+	}
+
+	for _, test := range tests {
+		got, err := ParseCIDRSlashValue(test.input)
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("ParseCIDRSlashValue(%q) = %d, %v, want %d, %v", test.input, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestParsePrefixLength(t *testing.T) {
+	tests := []struct {
+		input string
+		bits  int
+		want  int
+		err   error
+	}{
+		{"24", 32, 24, nil},
+		{"/24", 32, 24, nil},
+		{" / 24 ", 32, 24, nil},
+		{"255.255.255.0", 32, 24, nil},
+		{"/255.255.240.0", 32, 20, nil},
+		{"64", 128, 64, nil},
+		{"ffff:ffff:ffff:ffff::", 128, 64, nil},
+		{"33", 32, 0, ErrInvalidPrefixLength},
+		{"+24", 32, 0, ErrInvalidPrefixLength},
+		{"-0", 32, 0, ErrInvalidPrefixLength},
+		{"/+24", 32, 0, ErrInvalidPrefixLength},
+		{"", 32, 0, ErrInvalidPrefixLength},
+		{"255.0.255.0", 32, 0, ErrInvalidMask},
+		{"255.255.255", 32, 0, ErrInvalidMask},
+		{"ffff::", 32, 0, ErrFamilyMismatch},
+		{"255.255.255.0", 128, 0, ErrFamilyMismatch},
+	}
+
+	for _, test := range tests {
+		got, err := ParsePrefixLength(test.input, test.bits)
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("ParsePrefixLength(%q, %d) = %d, %v, want %d, %v", test.input, test.bits, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestParseHostPrefix(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		{"192.168.1.77/24", "192.168.1.77/24", nil},
+		{"192.168.1.77 255.255.255.0", "192.168.1.77/24", nil},
+		{"192.168.1.77/255.255.255.0", "192.168.1.77/24", nil},
+		{"  192.168.1.77\t255.255.255.0 ", "192.168.1.77/24", nil},
+		{"192.168.1.77 /24", "192.168.1.77/24", nil},
+		{"192.168.1.77 24", "192.168.1.77/24", nil},
+		{"2001:db8::1/64", "2001:db8::1/64", nil},
+		{"2001:db8::1 ffff:ffff:ffff:ffff::", "2001:db8::1/64", nil},
+		{"192.168.1.77", "", ErrInvalidPrefixLength},
+		{"192.168.1.77/33", "", ErrInvalidPrefixLength},
+		{"192.168.1.77 255.0.255.0", "", ErrInvalidMask},
+		{"192.168.1.77 ffff::", "", ErrFamilyMismatch},
+		{"192.168.1/24", "", ErrInvalidAddress},
+		{"fe80::1%eth0/64", "", ErrInvalidAddress},
+	}
+
+	for _, test := range tests {
+		got, err := ParseHostPrefix(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseHostPrefix(%q) error = %v, want %v", test.input, err, test.err)
+			continue
+		}
+
+		if err == nil && got.String() != test.want {
+			t.Errorf("ParseHostPrefix(%q) = %s, want %s", test.input, got, test.want)
+		}
+	}
+}
+
+func TestFormatCIDRSlashValue(t *testing.T) {
+	if got := FormatCIDRSlashValue(24); got != "/24" {
+		t.Errorf("FormatCIDRSlashValue(24) = %q, want %q", got, "/24")
+	}
+}
+
+func TestNetworkMaskToCIDRSlashValue(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+		err   error
+	}{
+		{"0.0.0.0", 0, nil},
+		{"255.0.0.0", 8, nil},
+		{"255.255.240.0", 20, nil},
+		{"255.255.255.255", 32, nil},
+		{"255.0.255.0", 0, ErrInvalidMask},
+		{"0.255.255.255", 0, ErrInvalidMask},
+		{"ffff::", 0, ErrNotIPv4},
+	}
+
+	for _, test := range tests {
+		got, err := NetworkMaskToCIDRSlashValue(netip.MustParseAddr(test.input))
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("NetworkMaskToCIDRSlashValue(%s) = %d, %v, want %d, %v", test.input, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestCIDRSlashValueToNetworkMask(t *testing.T) {
+	tests := []struct {
+		input int
+		want  string
+		err   error
+	}{
+		{0, "0.0.0.0", nil},
+		{1, "128.0.0.0", nil},
+		{20, "255.255.240.0", nil},
+		{32, "255.255.255.255", nil},
+		{33, "", ErrInvalidPrefixLength},
+		{-1, "", ErrInvalidPrefixLength},
+	}
+
+	for _, test := range tests {
+		got, err := CIDRSlashValueToNetworkMask(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("CIDRSlashValueToNetworkMask(%d) error = %v, want %v", test.input, err, test.err)
+			continue
+		}
+
+		if test.err == nil && got.String() != test.want {
+			t.Errorf("CIDRSlashValueToNetworkMask(%d) = %s, want %s", test.input, got, test.want)
+		}
+	}
+}
+
+func TestIPv6MaskConversions(t *testing.T) {
+	tests := []struct {
+		ones int
+		mask string
+	}{
+		{0, "::"},
+		{48, "ffff:ffff:ffff::"},
+		{56, "ffff:ffff:ffff:ff00::"},
+		{128, "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+	}
+
+	for _, test := range tests {
+		mask, err := CIDRSlashValueToIPv6Mask(test.ones)
+		if err != nil || mask.String() != test.mask {
+			t.Errorf("CIDRSlashValueToIPv6Mask(%d) = %s, %v, want %s", test.ones, mask, err, test.mask)
+		}
+
+		ones, err := IPv6MaskToCIDRSlashValue(netip.MustParseAddr(test.mask))
+		if err != nil || ones != test.ones {
+			t.Errorf("IPv6MaskToCIDRSlashValue(%s) = %d, %v, want %d", test.mask, ones, err, test.ones)
+		}
+	}
+
+	if _, err := CIDRSlashValueToIPv6Mask(129); !errors.Is(err, ErrInvalidPrefixLength) {
+		t.Errorf("CIDRSlashValueToIPv6Mask(129) error = %v, want %v", err, ErrInvalidPrefixLength)
+	}
+
+	if _, err := IPv6MaskToCIDRSlashValue(netip.MustParseAddr("ffff:0:ffff::")); !errors.Is(err, ErrInvalidMask) {
+		t.Errorf("IPv6MaskToCIDRSlashValue(ffff:0:ffff::) error = %v, want %v", err, ErrInvalidMask)
+	}
+
+	if _, err := IPv6MaskToCIDRSlashValue(netip.MustParseAddr("255.255.0.0")); !errors.Is(err, ErrNotIPv6) {
+		t.Errorf("IPv6MaskToCIDRSlashValue(255.255.0.0) error = %v, want %v", err, ErrNotIPv6)
+	}
+}
+
+func TestFindNetworkAddress(t *testing.T) {
+	tests := []struct {
+		host string
+		mask string
+		want string
+		err  error
+	}{
+		{"192.168.1.77", "255.255.255.0", "192.168.1.0/24", nil},
+		{"10.20.30.40", "255.240.0.0", "10.16.0.0/12", nil},
+		{"10.20.30.40", "255.255.255.255", "10.20.30.40/32", nil},
+		{"10.20.30.40", "0.0.0.0", "0.0.0.0/0", nil},
+		{"2001:db8:1:2::9", "ffff:ffff:ffff::", "2001:db8:1::/48", nil},
+		{"192.168.1.77", "255.0.255.0", "", ErrInvalidMask},
+		{"2001:db8::1", "255.255.255.0", "", ErrFamilyMismatch},
+	}
+
+	for _, test := range tests {
+		got, err := FindNetworkAddress(netip.MustParseAddr(test.host), netip.MustParseAddr(test.mask))
+		if !errors.Is(err, test.err) {
+			t.Errorf("FindNetworkAddress(%s, %s) error = %v, want %v", test.host, test.mask, err, test.err)
+			continue
+		}
+
+		if test.err == nil && got.String() != test.want {
+			t.Errorf("FindNetworkAddress(%s, %s) = %s, want %s", test.host, test.mask, got, test.want)
+		}
+	}
+
+	if _, err := FindNetworkAddress(netip.Addr{}, netip.MustParseAddr("255.0.0.0")); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("FindNetworkAddress(invalid, 255.0.0.0) error = %v, want %v", err, ErrInvalidAddress)
+	}
+}
+
+func TestNonContiguousMaskBit(t *testing.T) {
+	tests := []struct {
+		mask string
+		bit  int
+		ok   bool
+	}{
+		{"255.255.255.0", 0, false},
+		{"0.0.0.0", 0, false},
+		{"255.255.255.255", 0, false},
+		{"255.0.255.0", 16, true},
+		{"0.0.0.1", 31, true},
+		{"255.255.254.1", 31, true},
+		{"ffff:ffff::", 0, false},
+		{"ffff:0:ffff::", 32, true},
+	}
+
+	for _, test := range tests {
+		bit, ok := NonContiguousMaskBit(netip.MustParseAddr(test.mask))
+		if bit != test.bit || ok != test.ok {
+			t.Errorf("NonContiguousMaskBit(%s) = %d, %t, want %d, %t", test.mask, bit, ok, test.bit, test.ok)
+		}
+	}
+
+	_, err := NetworkMaskToCIDRSlashValue(netip.MustParseAddr("255.0.255.0"))
+	if err == nil || !strings.Contains(err.Error(), "bit 16") {
+		t.Errorf("NetworkMaskToCIDRSlashValue(255.0.255.0) error = %v, want it to name bit 16", err)
+	}
+}
+
+func TestApplyBitmask(t *testing.T) {
+	tests := []struct {
+		addr string
+		mask string
+		want string
+		err  error
+	}{
+		{"192.168.1.77", "255.255.255.0", "192.168.1.0", nil},
+		{"192.168.1.77", "255.0.255.0", "192.0.1.0", nil},
+		{"10.20.30.41", "0.0.0.1", "0.0.0.1", nil},
+		{"2001:db8:1:2::9", "ffff:0:ffff::ffff", "2001:0:1::9", nil},
+		{"2001:db8::1", "255.255.255.0", "", ErrFamilyMismatch},
+	}
+
+	for _, test := range tests {
+		got, err := ApplyBitmask(netip.MustParseAddr(test.addr), netip.MustParseAddr(test.mask))
+		if !errors.Is(err, test.err) {
+			t.Errorf("ApplyBitmask(%s, %s) error = %v, want %v", test.addr, test.mask, err, test.err)
+			continue
+		}
+
+		if test.err == nil && got.String() != test.want {
+			t.Errorf("ApplyBitmask(%s, %s) = %s, want %s", test.addr, test.mask, got, test.want)
+		}
+	}
+
+	if _, err := ApplyBitmask(netip.Addr{}, netip.MustParseAddr("255.0.0.0")); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("ApplyBitmask(invalid, 255.0.0.0) error = %v, want %v", err, ErrInvalidAddress)
+	}
+}
+
+func TestMaskRoundTrip(t *testing.T) {
+	roundTrip := func(ones uint8) bool {
+		ipv4Ones := int(ones) % 33
+		ipv4Mask, err := CIDRSlashValueToNetworkMask(ipv4Ones)
+		if err != nil {
+			return false
+		}
+
+		if got, err := NetworkMaskToCIDRSlashValue(ipv4Mask); err != nil || got != ipv4Ones {
+			return false
+		}
+
+		ipv6Ones := int(ones) % 129
+		ipv6Mask, err := CIDRSlashValueToIPv6Mask(ipv6Ones)
+		if err != nil {
+			return false
+		}
+
+		got, err := IPv6MaskToCIDRSlashValue(ipv6Mask)
+
+		return err == nil && got == ipv6Ones
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzParseHostPrefix(f *testing.F) {
+	f.Add("192.168.1.77/24")
+	f.Add("192.168.1.77 255.255.255.0")
+	f.Add("2001:db8::1/ffff::")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		prefix, err := ParseHostPrefix(input)
+		if err != nil {
+			return
+		}
+
+		if again, err := ParseHostPrefix(prefix.String()); err != nil || again != prefix {
+			t.Errorf("ParseHostPrefix(%q) = %s, which does not round trip: %s, %v", input, prefix, again, err)
+		}
+	})
+}
+
+func FuzzParseCIDRSlashValue(f *testing.F) {
+	f.Add("/24")
+	f.Add("/-0")
+	f.Add("24")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ones, err := ParseCIDRSlashValue(input)
+		if err == nil && (ones < 0 || ones > 128) {
+			t.Errorf("ParseCIDRSlashValue(%q) = %d, which is out of range", input, ones)
+		}
+	})
+}
+
+func FuzzParsePrefixLength(f *testing.F) {
+	f.Add("24")
+	f.Add("/64")
+	f.Add("255.255.255.0")
+	f.Add("ffff:ffff::")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, bits := range []int{32, 128} {
+			ones, err := ParsePrefixLength(input, bits)
+			if err != nil {
+				continue
+			}
+
+			if ones < 0 || ones > bits {
+				t.Fatalf("ParsePrefixLength(%q, %d) = %d, which is out of range", input, bits, ones)
+			}
+
+			if again, err := ParsePrefixLength(fmt.Sprintf("/%d", ones), bits); err != nil || again != ones {
+				t.Errorf("ParsePrefixLength(%q, %d) = %d, which does not round trip: %d, %v", input, bits, ones, again, err)
+			}
+		}
+	})
+}
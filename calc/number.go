@@ -0,0 +1,198 @@
+package calc
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	MinRadix = 2
+	MaxRadix = 36
+)
+
+func ParseDec(decimalNumber string) (int64, error) {
+	value, err := strconv.ParseInt(strings.TrimSpace(decimalNumber), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("ParseDec: %w", ErrInvalidDec)
+	}
+
+	return value, nil
+}
+
+func ParseHex(hexNumber string) (int64, error) {
+	value, err := strconv.ParseInt(strings.ReplaceAll(hexNumber, " ", ""), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("ParseHex: %w", ErrInvalidHex)
+	}
+
+	return value, nil
+}
+
+func ParseBin(binNumber string) (int64, error) {
+	value, err := strconv.ParseInt(strings.ReplaceAll(binNumber, " ", ""), 2, 64)
+	if err != nil {
+		return 0, fmt.Errorf("ParseBin: %w", ErrInvalidBin)
+	}
+
+	return value, nil
+}
+
+func FormatDec(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func FormatHex(value int64) string {
+	return strings.ToUpper(strconv.FormatInt(value, 16))
+}
+
+func FormatBin(value int64) string {
+	return strconv.FormatInt(value, 2)
+}
+
+// ParseBigNumber parses an arbitrarily large signed integer written in the
+// given radix. Spaces are ignored so that grouped digits can be pasted as is,
+// and digits above 9 are case insensitive.
+func ParseBigNumber(number string, radix int) (*big.Int, error) {
+	if radix < MinRadix || radix > MaxRadix {
+		return nil, fmt.Errorf("ParseBigNumber: %w", ErrInvalidRadix)
+	}
+
+	trimmedNumber := strings.Join(strings.Fields(number), "")
+
+	value, ok := new(big.Int).SetString(trimmedNumber, radix)
+	if !ok {
+		return nil, fmt.Errorf("ParseBigNumber: %w", radixError(radix))
+	}
+
+	return value, nil
+}
+
+// FormatBigNumber formats value in the given radix with upper case digits.
+// Binary is grouped in nibbles.
+func FormatBigNumber(value *big.Int, radix int) string {
+	if radix < MinRadix || radix > MaxRadix {
+		return ""
+	}
+
+	if radix == 2 {
+		sign := ""
+		if value.Sign() < 0 {
+			sign = "-"
+		}
+
+		return sign + FormatBinInNimbles(new(big.Int).Abs(value).Text(2))
+	}
+
+	return strings.ToUpper(value.Text(radix))
+}
+
+// EncodeTwosComplement returns the bit pattern of value at the given width.
+// Signed values must lie in [-2^(width-1), 2^(width-1)-1] and unsigned values
+// in [0, 2^width-1]; anything else is reported as ErrOutOfRange.
+func EncodeTwosComplement(value *big.Int, width int, signed bool) (*big.Int, error) {
+	if err := checkBitwiseWidth(width); err != nil {
+		return nil, fmt.Errorf("EncodeTwosComplement: %w", err)
+	}
+
+	min, max := twosComplementRange(width, signed)
+	if value.Cmp(min) < 0 || value.Cmp(max) > 0 {
+		return nil, fmt.Errorf("EncodeTwosComplement: %s does not fit in %d bits: %w", value, width, ErrOutOfRange)
+	}
+
+	return new(big.Int).And(value, bitwiseMask(width)), nil
+}
+
+// DecodeTwosComplement interprets a bit pattern of the given width as a signed
+// or unsigned value. Patterns wider than width are reported as ErrOutOfRange.
+func DecodeTwosComplement(pattern *big.Int, width int, signed bool) (*big.Int, error) {
+	if err := checkBitwiseWidth(width); err != nil {
+		return nil, fmt.Errorf("DecodeTwosComplement: %w", err)
+	}
+
+	if pattern.Sign() < 0 || pattern.BitLen() > width {
+		return nil, fmt.Errorf("DecodeTwosComplement: %s does not fit in %d bits: %w", pattern, width, ErrOutOfRange)
+	}
+
+	value := new(big.Int).Set(pattern)
+
+	if signed && pattern.Bit(width-1) == 1 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(width)))
+	}
+
+	return value, nil
+}
+
+func twosComplementRange(width int, signed bool) (*big.Int, *big.Int) {
+	if !signed {
+		return new(big.Int), bitwiseMask(width)
+	}
+
+	max := bitwiseMask(width - 1)
+
+	return new(big.Int).Not(max), max
+}
+
+func radixError(radix int) error {
+	switch radix {
+	case 2:
+		return ErrInvalidBin
+	case 8:
+		return ErrInvalidOct
+	case 10:
+		return ErrInvalidDec
+	case 16:
+		return ErrInvalidHex
+	default:
+		return ErrInvalidNumber
+	}
+}
+
+func ANDBinaryNumbers(binNumber1 string, binNumber2 string) (string, error) {
+	trimmedBin1 := strings.ReplaceAll(binNumber1, " ", "")
+	trimmedBin2 := strings.ReplaceAll(binNumber2, " ", "")
+
+	dec1, err := ParseBin(trimmedBin1)
+	if err != nil {
+		return "", fmt.Errorf("ANDBinaryNumbers: %w", ErrInvalidBin)
+	}
+
+	dec2, err := ParseBin(trimmedBin2)
+	if err != nil {
+		return "", fmt.Errorf("ANDBinaryNumbers: %w", ErrInvalidBin)
+	}
+
+	maxBinLength := len(trimmedBin1)
+	if len(trimmedBin2) > len(trimmedBin1) {
+		maxBinLength = len(trimmedBin2)
+	}
+
+	return FormatBinInNimbles(fmt.Sprintf("%0*b", maxBinLength, dec1&dec2)), nil
+}
+
+func FormatBinInNimbles(binNumber string) string {
+	var buf bytes.Buffer
+
+	trimmedBinNumber := strings.ReplaceAll(binNumber, " ", "")
+	leftMostNimbleEndIndex := len(trimmedBinNumber) % 4
+
+	if leftMostNimbleEndIndex != 0 {
+		buf.WriteString(trimmedBinNumber[:leftMostNimbleEndIndex])
+
+		if leftMostNimbleEndIndex+1 < len(trimmedBinNumber) {
+			buf.WriteByte(' ')
+		}
+	}
+
+	for i := leftMostNimbleEndIndex; i < len(trimmedBinNumber); i++ {
+		if i != leftMostNimbleEndIndex && (i-leftMostNimbleEndIndex)%4 == 0 {
+			buf.WriteByte(' ')
+		}
+
+		buf.WriteByte(trimmedBinNumber[i])
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,383 @@
+package calc
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestParseNumbers(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) (int64, error)
+		input string
+		want  int64
+		err   error
+	}{
+		{"ParseDec", ParseDec, "255", 255, nil},
+		{"ParseDec", ParseDec, " -5 ", -5, nil},
+		{"ParseDec", ParseDec, "12a", 0, ErrInvalidDec},
+		{"ParseDec", ParseDec, "", 0, ErrInvalidDec},
+		{"ParseHex", ParseHex, "FF", 255, nil},
+		{"ParseHex", ParseHex, "de ad", 0xDEAD, nil},
+		{"ParseHex", ParseHex, "G1", 0, ErrInvalidHex},
+		{"ParseBin", ParseBin, "1111 1111", 255, nil},
+		{"ParseBin", ParseBin, "102", 0, ErrInvalidBin},
+	}
+
+	for _, test := range tests {
+		got, err := test.parse(test.input)
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("%s(%q) = %d, %v, want %d, %v", test.name, test.input, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestFormatNumbers(t *testing.T) {
+	tests := []struct {
+		name   string
+		format func(int64) string
+		input  int64
+		want   string
+	}{
+		{"FormatDec", FormatDec, 255, "255"},
+		{"FormatHex", FormatHex, 255, "FF"},
+		{"FormatHex", FormatHex, 0, "0"},
+		{"FormatBin", FormatBin, 5, "101"},
+		{"FormatBin", FormatBin, 0, "0"},
+	}
+
+	for _, test := range tests {
+		if got := test.format(test.input); got != test.want {
+			t.Errorf("%s(%d) = %q, want %q", test.name, test.input, got, test.want)
+		}
+	}
+}
+
+func TestNumberRoundTrip(t *testing.T) {
+	roundTrip := func(value int64) bool {
+		fromDec, err := ParseDec(FormatDec(value))
+		if err != nil || fromDec != value {
+			return false
+		}
+
+		fromHex, err := ParseHex(FormatHex(value))
+		if err != nil || fromHex != value {
+			return false
+		}
+
+		fromBin, err := ParseBin(FormatBinInNimbles(FormatBin(value)))
+
+		return err == nil && fromBin == value
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseBigNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		radix int
+		want  string
+		err   error
+	}{
+		{"18446744073709551615", 10, "18446744073709551615", nil},
+		{"-170141183460469231731687303715884105728", 10, "-170141183460469231731687303715884105728", nil},
+		{"2001 0db8 0000 0000 0000 0000 0000 0001", 16, "42540766411282592856903984951653826561", nil},
+		{"FFFF FFFF FFFF FFFF", 16, "18446744073709551615", nil},
+		{"-ff", 16, "-255", nil},
+		{"1 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000 0000", 2, "18446744073709551616", nil},
+		{"777", 8, "511", nil},
+		{"zz", 36, "1295", nil},
+		{"Zz", 36, "1295", nil},
+		{"12a", 10, "", ErrInvalidDec},
+		{"G1", 16, "", ErrInvalidHex},
+		{"102", 2, "", ErrInvalidBin},
+		{"8", 8, "", ErrInvalidOct},
+		{"5", 5, "", ErrInvalidNumber},
+		{"", 10, "", ErrInvalidDec},
+		{"0x10", 16, "", ErrInvalidHex},
+		{"1", 1, "", ErrInvalidRadix},
+		{"1", 37, "", ErrInvalidRadix},
+	}
+
+	for _, test := range tests {
+		got, err := ParseBigNumber(test.input, test.radix)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseBigNumber(%q, %d) error = %v, want %v", test.input, test.radix, err, test.err)
+			continue
+		}
+
+		if err == nil && got.String() != test.want {
+			t.Errorf("ParseBigNumber(%q, %d) = %s, want %s", test.input, test.radix, got, test.want)
+		}
+	}
+}
+
+func TestFormatBigNumber(t *testing.T) {
+	counter64, _ := new(big.Int).SetString("18446744073709551615", 10)
+
+	tests := []struct {
+		input *big.Int
+		radix int
+		want  string
+	}{
+		{counter64, 16, "FFFFFFFFFFFFFFFF"},
+		{counter64, 8, "1777777777777777777777"},
+		{big.NewInt(-255), 16, "-FF"},
+		{big.NewInt(-95), 2, "-101 1111"},
+		{big.NewInt(0), 2, "0"},
+		{big.NewInt(1295), 36, "ZZ"},
+		{big.NewInt(1), 1, ""},
+	}
+
+	for _, test := range tests {
+		if got := FormatBigNumber(test.input, test.radix); got != test.want {
+			t.Errorf("FormatBigNumber(%s, %d) = %q, want %q", test.input, test.radix, got, test.want)
+		}
+	}
+}
+
+func TestBigNumberRoundTrip(t *testing.T) {
+	roundTrip := func(high int64, low uint64, radixIndex uint8) bool {
+		value := new(big.Int).Lsh(big.NewInt(high), 64)
+		value.Or(value, new(big.Int).SetUint64(low))
+
+		radix := MinRadix + int(radixIndex)%(MaxRadix-MinRadix+1)
+
+		again, err := ParseBigNumber(FormatBigNumber(value, radix), radix)
+
+		return err == nil && again.Cmp(value) == 0
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEncodeTwosComplement(t *testing.T) {
+	tests := []struct {
+		value  int64
+		width  int
+		signed bool
+		want   string
+		err    error
+	}{
+		{-1, 8, true, "FF", nil},
+		{-128, 8, true, "80", nil},
+		{127, 8, true, "7F", nil},
+		{128, 8, true, "", ErrOutOfRange},
+		{-129, 8, true, "", ErrOutOfRange},
+		{255, 8, false, "FF", nil},
+		{256, 8, false, "", ErrOutOfRange},
+		{-1, 8, false, "", ErrOutOfRange},
+		{-2, 64, true, "FFFFFFFFFFFFFFFE", nil},
+		{-1, 128, true, "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF", nil},
+		{1, 12, true, "", ErrInvalidWidth},
+	}
+
+	for _, test := range tests {
+		got, err := EncodeTwosComplement(big.NewInt(test.value), test.width, test.signed)
+		if !errors.Is(err, test.err) {
+			t.Errorf("EncodeTwosComplement(%d, %d, %t) error = %v, want %v", test.value, test.width, test.signed, err, test.err)
+			continue
+		}
+
+		if err == nil && FormatBigNumber(got, 16) != test.want {
+			t.Errorf("EncodeTwosComplement(%d, %d, %t) = %X, want %s", test.value, test.width, test.signed, got, test.want)
+		}
+	}
+}
+
+func TestDecodeTwosComplement(t *testing.T) {
+	tests := []struct {
+		pattern string
+		width   int
+		signed  bool
+		want    string
+		err     error
+	}{
+		{"FF", 8, true, "-1", nil},
+		{"FF", 8, false, "255", nil},
+		{"80", 8, true, "-128", nil},
+		{"7F", 8, true, "127", nil},
+		{"FF", 16, true, "255", nil},
+		{"FFFE", 16, true, "-2", nil},
+		{"100", 8, false, "", ErrOutOfRange},
+		{"-1", 8, true, "", ErrOutOfRange},
+		{"FFFFFFFFFFFFFFFF", 64, false, "18446744073709551615", nil},
+		{"FFFFFFFFFFFFFFFF", 64, true, "-1", nil},
+		{"1", 7, true, "", ErrInvalidWidth},
+	}
+
+	for _, test := range tests {
+		pattern, _ := ParseBigNumber(test.pattern, 16)
+
+		got, err := DecodeTwosComplement(pattern, test.width, test.signed)
+		if !errors.Is(err, test.err) {
+			t.Errorf("DecodeTwosComplement(%s, %d, %t) error = %v, want %v", test.pattern, test.width, test.signed, err, test.err)
+			continue
+		}
+
+		if err == nil && got.String() != test.want {
+			t.Errorf("DecodeTwosComplement(%s, %d, %t) = %s, want %s", test.pattern, test.width, test.signed, got, test.want)
+		}
+	}
+}
+
+func TestTwosComplementRoundTrip(t *testing.T) {
+	roundTrip := func(value int64, widthIndex uint8, signed bool) bool {
+		width := BitwiseWidths[int(widthIndex)%len(BitwiseWidths)]
+
+		pattern, err := EncodeTwosComplement(big.NewInt(value), width, signed)
+		if err != nil {
+			return errors.Is(err, ErrOutOfRange)
+		}
+
+		again, err := DecodeTwosComplement(pattern, width, signed)
+
+		return err == nil && again.Int64() == value
+	}
+
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestANDBinaryNumbers(t *testing.T) {
+	tests := []struct {
+		bin1 string
+		bin2 string
+		want string
+		err  error
+	}{
+		{"1100", "1010", "1000", nil},
+		{"1111 0000", "1", "0000 0000", nil},
+		{"0011", "11", "0011", nil},
+		{"2", "1", "", ErrInvalidBin},
+		{"1", "", "", ErrInvalidBin},
+	}
+
+	for _, test := range tests {
+		got, err := ANDBinaryNumbers(test.bin1, test.bin2)
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("ANDBinaryNumbers(%q, %q) = %q, %v, want %q, %v", test.bin1, test.bin2, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestFormatBinInNimbles(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"1", "1"},
+		{"1010", "1010"},
+		{"10101", "1 0101"},
+		{"1011111", "101 1111"},
+		{"11111111", "1111 1111"},
+		{"1111 1111", "1111 1111"},
+	}
+
+	for _, test := range tests {
+		if got := FormatBinInNimbles(test.input); got != test.want {
+			t.Errorf("FormatBinInNimbles(%q) = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func FuzzParseDec(f *testing.F) {
+	f.Add("255")
+	f.Add("-9223372036854775808")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		value, err := ParseDec(input)
+		if err != nil {
+			return
+		}
+
+		if again, err := ParseDec(FormatDec(value)); err != nil || again != value {
+			t.Errorf("ParseDec(FormatDec(%d)) = %d, %v", value, again, err)
+		}
+	})
+}
+
+func FuzzParseHex(f *testing.F) {
+	f.Add("FF")
+	f.Add("-7fff ffff ffff ffff")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		value, err := ParseHex(input)
+		if err != nil {
+			return
+		}
+
+		if again, err := ParseHex(FormatHex(value)); err != nil || again != value {
+			t.Errorf("ParseHex(FormatHex(%d)) = %d, %v", value, again, err)
+		}
+	})
+}
+
+func FuzzParseBin(f *testing.F) {
+	f.Add("1111 0000")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		value, err := ParseBin(input)
+		if err != nil {
+			return
+		}
+
+		if again, err := ParseBin(FormatBin(value)); err != nil || again != value {
+			t.Errorf("ParseBin(FormatBin(%d)) = %d, %v", value, again, err)
+		}
+	})
+}
+
+func FuzzParseBigNumber(f *testing.F) {
+	f.Add("18446744073709551615", uint8(8))
+	f.Add("-7fff ffff ffff ffff ffff", uint8(14))
+
+	f.Fuzz(func(t *testing.T, input string, radixIndex uint8) {
+		radix := MinRadix + int(radixIndex)%(MaxRadix-MinRadix+1)
+
+		value, err := ParseBigNumber(input, radix)
+		if err != nil {
+			return
+		}
+
+		if again, err := ParseBigNumber(FormatBigNumber(value, radix), radix); err != nil || again.Cmp(value) != 0 {
+			t.Errorf("ParseBigNumber(FormatBigNumber(%s, %d)) = %v, %v", value, radix, again, err)
+		}
+	})
+}
+
+func FuzzFormatBinInNimbles(f *testing.F) {
+	f.Add("1011111")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		got := FormatBinInNimbles(input)
+		if strings.ReplaceAll(got, " ", "") != strings.ReplaceAll(input, " ", "") {
+			t.Errorf("FormatBinInNimbles(%q) = %q changed the digits", input, got)
+		}
+	})
+}
+
+func FuzzANDBinaryNumbers(f *testing.F) {
+	f.Add("1100", "1010")
+
+	f.Fuzz(func(t *testing.T, bin1 string, bin2 string) {
+		got, err := ANDBinaryNumbers(bin1, bin2)
+		if err != nil {
+			return
+		}
+
+		if _, err := ParseBin(got); err != nil {
+			t.Errorf("ANDBinaryNumbers(%q, %q) = %q, which is not binary", bin1, bin2, got)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package calc
+
+import "fmt"
+
+// wellKnownPorts are the services most often seen in firewall rules and
+// packet captures, from the IANA Service Name and Transport Protocol Port
+// Number Registry.
+var wellKnownPorts = map[int]string{
+	20:   "FTP data",
+	21:   "FTP control",
+	22:   "SSH",
+	23:   "Telnet",
+	25:   "SMTP",
+	49:   "TACACS",
+	53:   "DNS",
+	67:   "DHCP server",
+	68:   "DHCP client",
+	69:   "TFTP",
+	80:   "HTTP",
+	88:   "Kerberos",
+	110:  "POP3",
+	123:  "NTP",
+	135:  "Microsoft RPC",
+	137:  "NetBIOS name service",
+	139:  "NetBIOS session service",
+	143:  "IMAP",
+	161:  "SNMP",
+	162:  "SNMP trap",
+	179:  "BGP",
+	389:  "LDAP",
+	443:  "HTTPS",
+	445:  "Microsoft SMB",
+	500:  "IKE (IPsec)",
+	514:  "Syslog",
+	520:  "RIP",
+	546:  "DHCPv6 client",
+	547:  "DHCPv6 server",
+	587:  "SMTP submission",
+	636:  "LDAPS",
+	646:  "LDP",
+	853:  "DNS over TLS",
+	989:  "FTPS data",
+	990:  "FTPS control",
+	993:  "IMAPS",
+	995:  "POP3S",
+	1812: "RADIUS authentication",
+	1813: "RADIUS accounting",
+	1900: "SSDP",
+	3389: "RDP",
+	4500: "IPsec NAT traversal",
+	4789: "VXLAN",
+	5060: "SIP",
+	5061: "SIP over TLS",
+	6514: "Syslog over TLS",
+	8080: "HTTP alternate",
+	8443: "HTTPS alternate",
+}
+
+// DescribePort returns the IANA port range the port number belongs to and
+// the service commonly using it, or "" if it is not in the built-in list.
+func DescribePort(port int) (string, string, error) {
+	var portRange string
+
+	switch {
+	case port < 0 || port > 65535:
+		return "", "", fmt.Errorf("DescribePort: %d: %w", port, ErrOutOfRange)
+	case port < 1024:
+		portRange = "system (well-known) port, 0-1023"
+	case port < 49152:
+		portRange = "user (registered) port, 1024-49151"
+	default:
+		portRange = "dynamic (private) port, 49152-65535"
+	}
+
+	return portRange, wellKnownPorts[port], nil
+}
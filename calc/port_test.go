@@ -0,0 +1,29 @@
+package calc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDescribePort(t *testing.T) {
+	tests := []struct {
+		port        int
+		wantRange   string
+		wantService string
+		wantErr     error
+	}{
+		{22, "system (well-known) port, 0-1023", "SSH", nil},
+		{1023, "system (well-known) port, 0-1023", "", nil},
+		{3389, "user (registered) port, 1024-49151", "RDP", nil},
+		{49152, "dynamic (private) port, 49152-65535", "", nil},
+		{65536, "", "", ErrOutOfRange},
+		{-1, "", "", ErrOutOfRange},
+	}
+
+	for _, test := range tests {
+		portRange, service, err := DescribePort(test.port)
+		if !errors.Is(err, test.wantErr) || portRange != test.wantRange || service != test.wantService {
+			t.Errorf("DescribePort(%d) = %q, %q, %v, want %q, %q, %v", test.port, portRange, service, err, test.wantRange, test.wantService, test.wantErr)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package calc
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The IANA IPv4 and IPv6 Special-Purpose Address Registries (RFC 6890), as
+// published in CSV form at https://www.iana.org/assignments/iana-ipv4-special-registry
+// and https://www.iana.org/assignments/iana-ipv6-special-registry.
+var (
+	//go:embed data/iana-ipv4-special-registry.csv
+	ipv4SpecialRegistry []byte
+
+	//go:embed data/iana-ipv6-special-registry.csv
+	ipv6SpecialRegistry []byte
+)
+
+// RegistryFlag is the value of a yes/no column of the special-purpose
+// registries. Deprecated blocks and some footnoted entries have no value.
+type RegistryFlag int
+
+const (
+	FlagNotApplicable RegistryFlag = iota
+	FlagFalse
+	FlagTrue
+)
+
+func (flag RegistryFlag) String() string {
+	switch flag {
+	case FlagFalse:
+		return "False"
+	case FlagTrue:
+		return "True"
+	default:
+		return "N/A"
+	}
+}
+
+func (flag RegistryFlag) MarshalText() ([]byte, error) {
+	return []byte(flag.String()), nil
+}
+
+type SpecialPurposeBlock struct {
+	Prefix             netip.Prefix
+	Name               string
+	RFC                string
+	AllocationDate     string
+	TerminationDate    string
+	Source             RegistryFlag
+	Destination        RegistryFlag
+	Forwardable        RegistryFlag
+	GloballyReachable  RegistryFlag
+	ReservedByProtocol RegistryFlag
+}
+
+var (
+	specialPurposeBlocks     []SpecialPurposeBlock
+	specialPurposeBlocksOnce sync.Once
+
+	registryFootnote = regexp.MustCompile(`\s*\[\d+\]`)
+	registryRFC      = regexp.MustCompile(`RFC(\d)`)
+)
+
+// SpecialPurposeBlocks returns every block of the embedded IPv4 and IPv6
+// special-purpose registries in registry order.
+func SpecialPurposeBlocks() []SpecialPurposeBlock {
+	specialPurposeBlocksOnce.Do(func() {
+		for _, registry := range [][]byte{ipv4SpecialRegistry, ipv6SpecialRegistry} {
+			blocks, err := parseSpecialRegistry(registry)
+			if err != nil {
+				panic(err)
+			}
+
+			specialPurposeBlocks = append(specialPurposeBlocks, blocks...)
+		}
+	})
+
+	return append([]SpecialPurposeBlock(nil), specialPurposeBlocks...)
+}
+
+// LookupSpecialPurpose returns the special-purpose blocks that contain
+// ipAddress, most specific first. IPv4-mapped IPv6 addresses only match the
+// IPv4-mapped block, not the IPv4 registry.
+func LookupSpecialPurpose(ipAddress netip.Addr) ([]SpecialPurposeBlock, error) {
+	if !ipAddress.IsValid() {
+		return nil, fmt.Errorf("LookupSpecialPurpose: %w", ErrInvalidAddress)
+	}
+
+	ipAddress = ipAddress.WithZone("")
+
+	var matches []SpecialPurposeBlock
+
+	for _, block := range SpecialPurposeBlocks() {
+		if block.Prefix.Contains(ipAddress) {
+			matches = append(matches, block)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Prefix.Bits() > matches[j].Prefix.Bits()
+	})
+
+	return matches, nil
+}
+
+func parseSpecialRegistry(registry []byte) ([]SpecialPurposeBlock, error) {
+	records, err := csv.NewReader(bytes.NewReader(registry)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parseSpecialRegistry: %w", err)
+	}
+
+	var blocks []SpecialPurposeBlock
+
+	for _, record := range records[1:] {
+		if len(record) != 10 {
+			return nil, fmt.Errorf("parseSpecialRegistry: %q has %d columns", record, len(record))
+		}
+
+		flags := make([]RegistryFlag, 0, 5)
+
+		for _, column := range record[5:] {
+			switch stripFootnotes(column) {
+			case "True":
+				flags = append(flags, FlagTrue)
+			case "False":
+				flags = append(flags, FlagFalse)
+			default:
+				flags = append(flags, FlagNotApplicable)
+			}
+		}
+
+		terminationDate := stripFootnotes(record[4])
+		if terminationDate == "N/A" {
+			terminationDate = ""
+		}
+
+		for _, addressBlock := range strings.Split(stripFootnotes(record[0]), ",") {
+			prefix, err := netip.ParsePrefix(strings.TrimSpace(addressBlock))
+			if err != nil {
+				return nil, fmt.Errorf("parseSpecialRegistry: %w", err)
+			}
+
+			blocks = append(blocks, SpecialPurposeBlock{
+				Prefix:             prefix,
+				Name:               strings.Trim(stripFootnotes(record[1]), `"`),
+				RFC:                formatRegistryReference(record[2]),
+				AllocationDate:     stripFootnotes(record[3]),
+				TerminationDate:    terminationDate,
+				Source:             flags[0],
+				Destination:        flags[1],
+				Forwardable:        flags[2],
+				GloballyReachable:  flags[3],
+				ReservedByProtocol: flags[4],
+			})
+		}
+	}
+
+	return blocks, nil
+}
+
+func stripFootnotes(column string) string {
+	return strings.TrimSpace(registryFootnote.ReplaceAllString(column, ""))
+}
+
+// formatRegistryReference turns "[RFC8880][RFC7050], Section 2.2" into
+// "RFC 8880, RFC 7050, Section 2.2".
+func formatRegistryReference(reference string) string {
+	reference = strings.NewReplacer("][", ", ", "]\n[", ", ", "\n", ", ", "[", "", "]", "").Replace(reference)
+
+	return registryRFC.ReplaceAllString(reference, "RFC $1")
+}
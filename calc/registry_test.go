@@ -0,0 +1,85 @@
+package calc
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestSpecialPurposeBlocks(t *testing.T) {
+	blocks := SpecialPurposeBlocks()
+	if len(blocks) < 40 {
+		t.Fatalf("SpecialPurposeBlocks() returned %d blocks", len(blocks))
+	}
+
+	for _, block := range blocks {
+		if !block.Prefix.IsValid() || block.Prefix != block.Prefix.Masked() || block.Name == "" || block.RFC == "" {
+			t.Errorf("SpecialPurposeBlocks() has malformed block %+v", block)
+		}
+	}
+}
+
+func TestLookupSpecialPurpose(t *testing.T) {
+	tests := []struct {
+		input   string
+		names   []string
+		rfc     string
+		global  RegistryFlag
+		forward RegistryFlag
+	}{
+		{"100.64.1.1", []string{"Shared Address Space"}, "RFC 6598", FlagFalse, FlagTrue},
+		{"192.0.2.10", []string{"Documentation (TEST-NET-1)"}, "RFC 5737", FlagFalse, FlagFalse},
+		{"198.51.100.1", []string{"Documentation (TEST-NET-2)"}, "RFC 5737", FlagFalse, FlagFalse},
+		{"203.0.113.99", []string{"Documentation (TEST-NET-3)"}, "RFC 5737", FlagFalse, FlagFalse},
+		{"198.19.255.1", []string{"Benchmarking"}, "RFC 2544", FlagFalse, FlagTrue},
+		{"192.0.0.9", []string{"Port Control Protocol Anycast", "IETF Protocol Assignments"}, "RFC 7723", FlagTrue, FlagTrue},
+		{"192.0.0.171", []string{"NAT64/DNS64 Discovery", "IETF Protocol Assignments"}, "RFC 8880, RFC 7050, Section 2.2", FlagFalse, FlagFalse},
+		{"192.88.99.2", []string{"6a44-relay anycast address", "Deprecated (6to4 Relay Anycast)"}, "RFC 6751", FlagFalse, FlagTrue},
+		{"0.0.0.0", []string{"This host on this network", "This network"}, "RFC 1122, Section 3.2.1.3", FlagFalse, FlagFalse},
+		{"255.255.255.255", []string{"Limited Broadcast", "Reserved"}, "RFC 8190, RFC 919, Section 7", FlagFalse, FlagFalse},
+		{"100::1", []string{"Discard-Only Address Block"}, "RFC 6666", FlagFalse, FlagTrue},
+		{"2001:20::1", []string{"ORCHIDv2", "IETF Protocol Assignments"}, "RFC 7343", FlagTrue, FlagTrue},
+		{"2001:2::1", []string{"Benchmarking", "IETF Protocol Assignments"}, "RFC 5180, RFC Errata 1752", FlagFalse, FlagTrue},
+		{"2001::1", []string{"TEREDO", "IETF Protocol Assignments"}, "RFC 4380, RFC 8190", FlagNotApplicable, FlagTrue},
+		{"2002::1", []string{"6to4"}, "RFC 3056", FlagNotApplicable, FlagTrue},
+		{"fe80::1%eth0", []string{"Link-Local Unicast"}, "RFC 4291", FlagFalse, FlagFalse},
+		{"::ffff:10.0.0.1", []string{"IPv4-mapped Address"}, "RFC 4291", FlagFalse, FlagFalse},
+		{"8.8.8.8", nil, "", 0, 0},
+		{"2606:4700::1111", nil, "", 0, 0},
+	}
+
+	for _, test := range tests {
+		got, err := LookupSpecialPurpose(netip.MustParseAddr(test.input))
+		if err != nil {
+			t.Errorf("LookupSpecialPurpose(%s) error = %v", test.input, err)
+			continue
+		}
+
+		if len(got) != len(test.names) {
+			t.Errorf("LookupSpecialPurpose(%s) = %d blocks, want %v", test.input, len(got), test.names)
+			continue
+		}
+
+		for i, block := range got {
+			if block.Name != test.names[i] {
+				t.Errorf("LookupSpecialPurpose(%s)[%d].Name = %q, want %q", test.input, i, block.Name, test.names[i])
+			}
+		}
+
+		if len(got) > 0 && (got[0].RFC != test.rfc || got[0].GloballyReachable != test.global || got[0].Forwardable != test.forward) {
+			t.Errorf("LookupSpecialPurpose(%s)[0] = %+v, want RFC %q, globally reachable %s, forwardable %s",
+				test.input, got[0], test.rfc, test.global, test.forward)
+		}
+	}
+
+	if _, err := LookupSpecialPurpose(netip.Addr{}); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("LookupSpecialPurpose(invalid) error = %v, want %v", err, ErrInvalidAddress)
+	}
+}
+
+func TestDeprecatedBlocksHaveTerminationDate(t *testing.T) {
+	blocks, _ := LookupSpecialPurpose(netip.MustParseAddr("2001:10::1"))
+	if len(blocks) == 0 || blocks[0].TerminationDate != "2014-03" || blocks[0].Source != FlagNotApplicable {
+		t.Errorf("LookupSpecialPurpose(2001:10::1) = %+v, want the terminated ORCHID block", blocks)
+	}
+}
@@ -0,0 +1,234 @@
+package calc
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+const MaxListedSubnets = 4096
+
+type Subnet struct {
+	Prefix          netip.Prefix
+	NetworkMask     netip.Addr
+	FirstUsableHost netip.Addr
+	LastUsableHost  netip.Addr
+	Broadcast       netip.Addr
+	UsableHosts     uint64
+}
+
+type SubnetDetails struct {
+	Subnet
+	HostAddress    netip.Addr
+	WildcardMask   netip.Addr
+	TotalAddresses uint64
+	Class          string
+}
+
+// DescribeSubnet reports everything about the IPv4 network that contains
+// hostIPAddress. /31 networks have two usable hosts (RFC 3021) and a /32 has
+// one, in both cases without a separate network or broadcast address.
+func DescribeSubnet(hostIPAddress netip.Addr, networkMask netip.Addr) (SubnetDetails, error) {
+	if !hostIPAddress.Is4() || !networkMask.Is4() {
+		return SubnetDetails{}, fmt.Errorf("DescribeSubnet: %w", ErrNotIPv4)
+	}
+
+	ones, err := maskOnes(networkMask)
+	if err != nil {
+		return SubnetDetails{}, fmt.Errorf("DescribeSubnet: %w", err)
+	}
+
+	hostBits := 32 - ones
+	start := ipv4ToUint32(hostIPAddress) &^ (uint32(uint64(1)<<hostBits - 1))
+	end := start | uint32(uint64(1)<<hostBits-1)
+	class, _ := IPv4AddressClass(hostIPAddress)
+
+	return SubnetDetails{
+		Subnet:         newSubnet(start, end, ones),
+		HostAddress:    hostIPAddress,
+		WildcardMask:   uint32ToIPv4(^ipv4ToUint32(networkMask)),
+		TotalAddresses: uint64(1) << hostBits,
+		Class:          class,
+	}, nil
+}
+
+func SplitNetworkBySubnetCount(network netip.Prefix, subnetCount int) ([]Subnet, []string, error) {
+	networkAddr, ones, err := ipv4Network(network)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SplitNetworkBySubnetCount: %w", err)
+	}
+
+	if subnetCount < 1 {
+		return nil, nil, fmt.Errorf("SplitNetworkBySubnetCount: %w", ErrInvalidCount)
+	}
+
+	hostBits := 32 - ones
+	borrowedBits := 0
+
+	// Stopping at hostBits keeps the shift from overflowing for huge counts.
+	for borrowedBits <= hostBits && 1<<borrowedBits < subnetCount {
+		borrowedBits++
+	}
+
+	if borrowedBits > hostBits {
+		return nil, nil, fmt.Errorf("SplitNetworkBySubnetCount: /%d has only %d host bits, cannot make %d subnets: %w", ones, hostBits, subnetCount, ErrDoesNotFit)
+	}
+
+	steps := []string{
+		fmt.Sprintf("Parent network %s/%d has %d host bits (%d addresses).", uint32ToIPv4(networkAddr), ones, hostBits, uint64(1)<<hostBits),
+		fmt.Sprintf("To get at least %d subnets, borrow %d bits from the host part since 2^%d = %d >= %d.", subnetCount, borrowedBits, borrowedBits, 1<<borrowedBits, subnetCount),
+	}
+
+	return splitNetwork(networkAddr, ones, borrowedBits, steps)
+}
+
+func SplitNetworkByHostCount(network netip.Prefix, hostsPerSubnet int) ([]Subnet, []string, error) {
+	networkAddr, ones, err := ipv4Network(network)
+	if err != nil {
+		return nil, nil, fmt.Errorf("SplitNetworkByHostCount: %w", err)
+	}
+
+	if hostsPerSubnet < 1 {
+		return nil, nil, fmt.Errorf("SplitNetworkByHostCount: %w", ErrInvalidCount)
+	}
+
+	hostBits := 32 - ones
+	neededHostBits := hostBitsForHostCount(uint64(hostsPerSubnet))
+
+	if neededHostBits > hostBits {
+		return nil, nil, fmt.Errorf("SplitNetworkByHostCount: /%d has only %d host bits, cannot fit %d hosts per subnet: %w", ones, hostBits, hostsPerSubnet, ErrDoesNotFit)
+	}
+
+	borrowedBits := hostBits - neededHostBits
+
+	steps := []string{
+		fmt.Sprintf("Parent network %s/%d has %d host bits (%d addresses).", uint32ToIPv4(networkAddr), ones, hostBits, uint64(1)<<hostBits),
+		fmt.Sprintf("To fit %d hosts per subnet, keep %d host bits since %s = %d >= %d.", hostsPerSubnet, neededHostBits, usableHostFormula(neededHostBits), usableHostCount(neededHostBits), hostsPerSubnet),
+		fmt.Sprintf("The remaining %d - %d = %d host bits are borrowed for the subnet part.", hostBits, neededHostBits, borrowedBits),
+	}
+
+	return splitNetwork(networkAddr, ones, borrowedBits, steps)
+}
+
+func splitNetwork(networkAddr uint32, ones int, borrowedBits int, steps []string) ([]Subnet, []string, error) {
+	subnetCount := uint64(1) << borrowedBits
+	if subnetCount > MaxListedSubnets {
+		return nil, nil, fmt.Errorf("splitNetwork: %d subnets is more than the %d that can be listed: %w", subnetCount, MaxListedSubnets, ErrTooManySubnets)
+	}
+
+	newOnes := ones + borrowedBits
+	newHostBits := 32 - newOnes
+	blockSize := uint64(1) << newHostBits
+
+	steps = append(steps,
+		fmt.Sprintf("New prefix length is /%d + %d = /%d, network mask %s.", ones, borrowedBits, newOnes, maskFromOnes(newOnes, 32)),
+		fmt.Sprintf("Each subnet has 2^%d = %d addresses and %s = %d usable hosts.", newHostBits, blockSize, usableHostFormula(newHostBits), usableHostCount(newHostBits)),
+	)
+
+	if borrowedBits == 0 {
+		steps = append(steps, "No bits are borrowed, so the only subnet is the parent network itself.")
+	} else {
+		steps = append(steps, fmt.Sprintf("There are 2^%d = %d subnets with a block size of %s.", borrowedBits, subnetCount, blockSizeDescription(blockSize)))
+	}
+
+	subnets := make([]Subnet, 0, subnetCount)
+
+	for i := uint64(0); i < subnetCount; i++ {
+		start := networkAddr + uint32(i*blockSize)
+		end := start + uint32(blockSize-1)
+
+		subnets = append(subnets, newSubnet(start, end, newOnes))
+	}
+
+	return subnets, steps, nil
+}
+
+func newSubnet(start uint32, end uint32, ones int) Subnet {
+	hostBits := 32 - ones
+	firstUsable, lastUsable := start, end
+
+	if hostBits >= 2 {
+		firstUsable++
+		lastUsable--
+	}
+
+	return Subnet{
+		Prefix:          netip.PrefixFrom(uint32ToIPv4(start), ones),
+		NetworkMask:     maskFromOnes(ones, 32),
+		FirstUsableHost: uint32ToIPv4(firstUsable),
+		LastUsableHost:  uint32ToIPv4(lastUsable),
+		Broadcast:       uint32ToIPv4(end),
+		UsableHosts:     usableHostCount(hostBits),
+	}
+}
+
+func hostBitsForHostCount(hosts uint64) int {
+	hostBits := 0
+
+	for hostBits <= 32 && usableHostCount(hostBits) < hosts {
+		hostBits++
+	}
+
+	return hostBits
+}
+
+// usableHostCount follows RFC 3021 for /31 networks, which have two usable
+// point-to-point addresses, and treats a /32 as a single host.
+func usableHostCount(hostBits int) uint64 {
+	switch hostBits {
+	case 0:
+		return 1
+	case 1:
+		return 2
+	default:
+		return (uint64(1) << hostBits) - 2
+	}
+}
+
+func usableHostFormula(hostBits int) string {
+	switch hostBits {
+	case 0:
+		return "2^0 (/32 host route)"
+	case 1:
+		return "2^1 (/31 point-to-point, RFC 3021)"
+	default:
+		return fmt.Sprintf("2^%d - 2", hostBits)
+	}
+}
+
+func blockSizeDescription(blockSize uint64) string {
+	octet := 4
+
+	for blockSize > 256 && octet > 1 {
+		blockSize /= 256
+		octet--
+	}
+
+	if blockSize == 256 {
+		blockSize = 1
+		octet--
+	}
+
+	return fmt.Sprintf("%d in octet %d", blockSize, octet)
+}
+
+func ipv4Network(network netip.Prefix) (uint32, int, error) {
+	if !network.IsValid() {
+		return 0, 0, ErrInvalidAddress
+	}
+
+	if !network.Addr().Is4() {
+		return 0, 0, ErrNotIPv4
+	}
+
+	return ipv4ToUint32(network.Masked().Addr()), network.Bits(), nil
+}
+
+func ipv4ToUint32(ipv4 netip.Addr) uint32 {
+	octets := ipv4.As4()
+
+	return uint32(octets[0])<<24 | uint32(octets[1])<<16 | uint32(octets[2])<<8 | uint32(octets[3])
+}
+
+func uint32ToIPv4(value uint32) netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)})
+}
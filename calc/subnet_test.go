@@ -0,0 +1,165 @@
+package calc
+
+import (
+	"errors"
+	"math"
+	"net/netip"
+	"testing"
+)
+
+func TestSplitNetworkBySubnetCount(t *testing.T) {
+	tests := []struct {
+		network    string
+		count      int
+		wantCount  int
+		wantPrefix string
+		wantLast   string
+		wantUsable uint64
+		wantErr    error
+	}{
+		{"192.168.1.0/24", 5, 8, "192.168.1.0/27", "192.168.1.224/27", 30, nil},
+		{"192.168.1.77/24", 2, 2, "192.168.1.0/25", "192.168.1.128/25", 126, nil},
+		{"10.0.0.0/8", 1, 1, "10.0.0.0/8", "10.0.0.0/8", 16777214, nil},
+		{"10.0.0.0/30", 4, 4, "10.0.0.0/32", "10.0.0.3/32", 1, nil},
+		{"10.0.0.0/30", 5, 0, "", "", 0, ErrDoesNotFit},
+		{"10.0.0.0/8", math.MaxInt, 0, "", "", 0, ErrDoesNotFit},
+		{"10.0.0.0/8", 5000, 0, "", "", 0, ErrTooManySubnets},
+		{"10.0.0.0/8", 0, 0, "", "", 0, ErrInvalidCount},
+		{"2001:db8::/32", 2, 0, "", "", 0, ErrNotIPv4},
+	}
+
+	for _, test := range tests {
+		subnets, steps, err := SplitNetworkBySubnetCount(netip.MustParsePrefix(test.network), test.count)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("SplitNetworkBySubnetCount(%s, %d) error = %v, want %v", test.network, test.count, err, test.wantErr)
+			continue
+		}
+
+		if test.wantErr != nil {
+			continue
+		}
+
+		if len(subnets) != test.wantCount || len(steps) == 0 {
+			t.Errorf("SplitNetworkBySubnetCount(%s, %d) returned %d subnets and %d steps, want %d subnets", test.network, test.count, len(subnets), len(steps), test.wantCount)
+			continue
+		}
+
+		first, last := subnets[0], subnets[len(subnets)-1]
+		if first.Prefix.String() != test.wantPrefix || last.Prefix.String() != test.wantLast || first.UsableHosts != test.wantUsable {
+			t.Errorf("SplitNetworkBySubnetCount(%s, %d) = %s ... %s with %d usable hosts, want %s ... %s with %d", test.network, test.count,
+				first.Prefix, last.Prefix, first.UsableHosts, test.wantPrefix, test.wantLast, test.wantUsable)
+		}
+	}
+}
+
+func TestSplitNetworkByHostCount(t *testing.T) {
+	tests := []struct {
+		network   string
+		hosts     int
+		wantCount int
+		wantBits  int
+		wantErr   error
+	}{
+		{"192.168.1.0/24", 50, 4, 26, nil},
+		{"192.168.1.0/24", 62, 4, 26, nil},
+		{"192.168.1.0/24", 63, 2, 25, nil},
+		{"192.168.1.0/24", 254, 1, 24, nil},
+		{"192.168.1.0/24", 2, 128, 31, nil},
+		{"192.168.1.0/24", 255, 0, 0, ErrDoesNotFit},
+		{"192.168.1.0/24", 0, 0, 0, ErrInvalidCount},
+	}
+
+	for _, test := range tests {
+		subnets, _, err := SplitNetworkByHostCount(netip.MustParsePrefix(test.network), test.hosts)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("SplitNetworkByHostCount(%s, %d) error = %v, want %v", test.network, test.hosts, err, test.wantErr)
+			continue
+		}
+
+		if test.wantErr == nil && (len(subnets) != test.wantCount || subnets[0].Prefix.Bits() != test.wantBits) {
+			t.Errorf("SplitNetworkByHostCount(%s, %d) = %d subnets of /%d, want %d of /%d", test.network, test.hosts,
+				len(subnets), subnets[0].Prefix.Bits(), test.wantCount, test.wantBits)
+		}
+	}
+}
+
+func TestSubnetHostRange(t *testing.T) {
+	tests := []struct {
+		prefix    string
+		first     string
+		last      string
+		broadcast string
+		usable    uint64
+	}{
+		{"192.168.1.64/26", "192.168.1.65", "192.168.1.126", "192.168.1.127", 62},
+		{"10.0.0.0/31", "10.0.0.0", "10.0.0.1", "10.0.0.1", 2},
+		{"10.0.0.5/32", "10.0.0.5", "10.0.0.5", "10.0.0.5", 1},
+	}
+
+	for _, test := range tests {
+		subnets, _, err := SplitNetworkBySubnetCount(netip.MustParsePrefix(test.prefix), 1)
+		if err != nil {
+			t.Fatalf("SplitNetworkBySubnetCount(%s, 1) error = %v", test.prefix, err)
+		}
+
+		subnet := subnets[0]
+		if subnet.FirstUsableHost.String() != test.first || subnet.LastUsableHost.String() != test.last ||
+			subnet.Broadcast.String() != test.broadcast || subnet.UsableHosts != test.usable {
+			t.Errorf("%s: got %s - %s broadcast %s (%d usable), want %s - %s broadcast %s (%d usable)", test.prefix,
+				subnet.FirstUsableHost, subnet.LastUsableHost, subnet.Broadcast, subnet.UsableHosts,
+				test.first, test.last, test.broadcast, test.usable)
+		}
+	}
+}
+
+func TestDescribeSubnet(t *testing.T) {
+	tests := []struct {
+		host      string
+		mask      string
+		prefix    string
+		first     string
+		last      string
+		broadcast string
+		wildcard  string
+		total     uint64
+		usable    uint64
+		class     string
+	}{
+		{"192.168.1.77", "255.255.255.0", "192.168.1.0/24", "192.168.1.1", "192.168.1.254", "192.168.1.255", "0.0.0.255", 256, 254, "C"},
+		{"172.20.5.9", "255.255.240.0", "172.20.0.0/20", "172.20.0.1", "172.20.15.254", "172.20.15.255", "0.0.15.255", 4096, 4094, "B"},
+		{"10.0.0.1", "255.255.255.254", "10.0.0.0/31", "10.0.0.0", "10.0.0.1", "10.0.0.1", "0.0.0.1", 2, 2, "A"},
+		{"10.0.0.7", "255.255.255.255", "10.0.0.7/32", "10.0.0.7", "10.0.0.7", "10.0.0.7", "0.0.0.0", 1, 1, "A"},
+		{"8.8.8.8", "0.0.0.0", "0.0.0.0/0", "0.0.0.1", "255.255.255.254", "255.255.255.255", "255.255.255.255", 1 << 32, 1<<32 - 2, "A"},
+	}
+
+	for _, test := range tests {
+		got, err := DescribeSubnet(netip.MustParseAddr(test.host), netip.MustParseAddr(test.mask))
+		if err != nil {
+			t.Errorf("DescribeSubnet(%s, %s) error = %v", test.host, test.mask, err)
+			continue
+		}
+
+		if got.Prefix.String() != test.prefix || got.FirstUsableHost.String() != test.first ||
+			got.LastUsableHost.String() != test.last || got.Broadcast.String() != test.broadcast ||
+			got.WildcardMask.String() != test.wildcard || got.TotalAddresses != test.total ||
+			got.UsableHosts != test.usable || got.Class != test.class || got.HostAddress.String() != test.host {
+			t.Errorf("DescribeSubnet(%s, %s) = %+v", test.host, test.mask, got)
+		}
+	}
+
+	invalid := []struct {
+		host string
+		mask string
+		err  error
+	}{
+		{"192.168.1.1", "255.0.255.0", ErrInvalidMask},
+		{"2001:db8::1", "255.255.255.0", ErrNotIPv4},
+		{"192.168.1.1", "ffff::", ErrNotIPv4},
+	}
+
+	for _, test := range invalid {
+		if _, err := DescribeSubnet(netip.MustParseAddr(test.host), netip.MustParseAddr(test.mask)); !errors.Is(err, test.err) {
+			t.Errorf("DescribeSubnet(%s, %s) error = %v, want %v", test.host, test.mask, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package calc
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type VLSMRequirement struct {
+	Name  string
+	Hosts uint64
+}
+
+type VLSMAllocation struct {
+	VLSMRequirement
+	Subnet
+
+	WastedHosts uint64
+}
+
+type VLSMPlan struct {
+	Allocations   []VLSMAllocation
+	FreeBlocks    []netip.Prefix
+	FreeAddresses uint64
+}
+
+var vlsmRequirementPattern = regexp.MustCompile(`^(.*?)\s*(\d+)\s*(?:hosts?)?$`)
+
+// ParseVLSMRequirements parses a list such as "Sales 120 hosts, WAN link 2"
+// separated by commas, semicolons or new lines.
+func ParseVLSMRequirements(requirements string) ([]VLSMRequirement, error) {
+	var parsed []VLSMRequirement
+
+	entries := strings.FieldsFunc(requirements, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		match := vlsmRequirementPattern.FindStringSubmatch(entry)
+		if match == nil {
+			return nil, fmt.Errorf("ParseVLSMRequirements: %q is not in the form \"<name> <hosts>\": %w", entry, ErrInvalidRequirement)
+		}
+
+		hosts, err := strconv.ParseUint(match[2], 10, 32)
+		if err != nil || hosts == 0 {
+			return nil, fmt.Errorf("ParseVLSMRequirements: %q has an invalid host count: %w", entry, ErrInvalidRequirement)
+		}
+
+		name := match[1]
+		if name == "" {
+			name = fmt.Sprintf("Subnet %d", len(parsed)+1)
+		}
+
+		parsed = append(parsed, VLSMRequirement{Name: name, Hosts: hosts})
+	}
+
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("ParseVLSMRequirements: %w", ErrEmptyInput)
+	}
+
+	return parsed, nil
+}
+
+func PlanVLSM(network netip.Prefix, requirements []VLSMRequirement) (VLSMPlan, []string, error) {
+	networkAddr, ones, err := ipv4Network(network)
+	if err != nil {
+		return VLSMPlan{}, nil, fmt.Errorf("PlanVLSM: %w", err)
+	}
+
+	if len(requirements) == 0 {
+		return VLSMPlan{}, nil, fmt.Errorf("PlanVLSM: %w", ErrEmptyInput)
+	}
+
+	sorted := make([]VLSMRequirement, len(requirements))
+	copy(sorted, requirements)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Hosts > sorted[j].Hosts
+	})
+
+	parentSize := uint64(1) << (32 - ones)
+	requiredAddresses := uint64(0)
+	names := make([]string, 0, len(sorted))
+
+	for _, requirement := range sorted {
+		requiredAddresses += uint64(1) << hostBitsForHostCount(requirement.Hosts)
+		names = append(names, fmt.Sprintf("%s (%d)", requirement.Name, requirement.Hosts))
+	}
+
+	steps := []string{
+		fmt.Sprintf("Parent network %s/%d has %d addresses.", uint32ToIPv4(networkAddr), ones, parentSize),
+		fmt.Sprintf("Sort the requirements largest first: %s.", strings.Join(names, ", ")),
+		"Allocating largest first keeps every block aligned to its own size.",
+	}
+
+	plan := VLSMPlan{}
+	cursor := uint64(networkAddr)
+	parentEnd := uint64(networkAddr) + parentSize
+
+	for _, requirement := range sorted {
+		hostBits := hostBitsForHostCount(requirement.Hosts)
+		blockSize := uint64(1) << hostBits
+
+		if hostBits > 32-ones || cursor+blockSize > parentEnd {
+			remaining := parentEnd - cursor
+
+			return VLSMPlan{}, steps, fmt.Errorf("PlanVLSM: %s needs a /%d (%d addresses) but only %d of the %d addresses in %s/%d remain; the requirements need %d addresses in total: %w",
+				requirement.Name, 32-hostBits, blockSize, remaining, parentSize, uint32ToIPv4(networkAddr), ones, requiredAddresses, ErrDoesNotFit)
+		}
+
+		subnet := newSubnet(uint32(cursor), uint32(cursor+blockSize-1), 32-hostBits)
+		allocation := VLSMAllocation{
+			VLSMRequirement: requirement,
+			Subnet:          subnet,
+			WastedHosts:     subnet.UsableHosts - requirement.Hosts,
+		}
+		plan.Allocations = append(plan.Allocations, allocation)
+
+		steps = append(steps, fmt.Sprintf("%s needs %d hosts: %d host bits since %s = %d >= %d, so allocate %s.",
+			requirement.Name, requirement.Hosts, hostBits, usableHostFormula(hostBits), subnet.UsableHosts, requirement.Hosts,
+			subnet.Prefix))
+
+		cursor += blockSize
+	}
+
+	plan.FreeAddresses = parentEnd - cursor
+	if plan.FreeAddresses > 0 {
+		plan.FreeBlocks = rangeToIPv4Blocks(cursor, parentEnd-1)
+
+		freeBlocks := make([]string, 0, len(plan.FreeBlocks))
+		for _, block := range plan.FreeBlocks {
+			freeBlocks = append(freeBlocks, block.String())
+		}
+
+		steps = append(steps, fmt.Sprintf("%d addresses remain free: %s.", plan.FreeAddresses, strings.Join(freeBlocks, ", ")))
+	} else {
+		steps = append(steps, "The parent network is fully allocated.")
+	}
+
+	return plan, steps, nil
+}
+
+// rangeToIPv4Blocks splits the inclusive range [start, end] into the fewest
+// aligned CIDR blocks.
+func rangeToIPv4Blocks(start uint64, end uint64) []netip.Prefix {
+	var blocks []netip.Prefix
+
+	for start <= end {
+		hostBits := 0
+
+		for hostBits < 32 {
+			size := uint64(1) << (hostBits + 1)
+			if start%size != 0 || start+size-1 > end {
+				break
+			}
+
+			hostBits++
+		}
+
+		blocks = append(blocks, netip.PrefixFrom(uint32ToIPv4(uint32(start)), 32-hostBits))
+		start += uint64(1) << hostBits
+	}
+
+	return blocks
+}
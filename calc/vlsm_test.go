@@ -0,0 +1,114 @@
+package calc
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestParseVLSMRequirements(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []VLSMRequirement
+		err   error
+	}{
+		{"Sales 120 hosts, WAN link 2", []VLSMRequirement{{"Sales", 120}, {"WAN link", 2}}, nil},
+		{"Lab 1 host;\nOffice 30", []VLSMRequirement{{"Lab", 1}, {"Office", 30}}, nil},
+		{"10, 20", []VLSMRequirement{{"Subnet 1", 10}, {"Subnet 2", 20}}, nil},
+		{"Sales", nil, ErrInvalidRequirement},
+		{"Sales 0", nil, ErrInvalidRequirement},
+		{" , ", nil, ErrEmptyInput},
+	}
+
+	for _, test := range tests {
+		got, err := ParseVLSMRequirements(test.input)
+		if !errors.Is(err, test.err) || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseVLSMRequirements(%q) = %v, %v, want %v, %v", test.input, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestPlanVLSM(t *testing.T) {
+	requirements := []VLSMRequirement{{"WAN link", 2}, {"Sales", 120}, {"Engineering", 50}}
+
+	plan, steps, err := PlanVLSM(netip.MustParsePrefix("192.168.1.0/24"), requirements)
+	if err != nil {
+		t.Fatalf("PlanVLSM error = %v", err)
+	}
+
+	want := []struct {
+		name   string
+		prefix string
+		wasted uint64
+	}{
+		{"Sales", "192.168.1.0/25", 6},
+		{"Engineering", "192.168.1.128/26", 12},
+		{"WAN link", "192.168.1.192/31", 0},
+	}
+
+	if len(plan.Allocations) != len(want) || len(steps) == 0 {
+		t.Fatalf("PlanVLSM returned %d allocations and %d steps, want %d allocations", len(plan.Allocations), len(steps), len(want))
+	}
+
+	for i, allocation := range plan.Allocations {
+		if allocation.Name != want[i].name || allocation.Prefix.String() != want[i].prefix || allocation.WastedHosts != want[i].wasted {
+			t.Errorf("allocation %d = %s %s (%d wasted), want %s %s (%d wasted)", i, allocation.Name, allocation.Prefix,
+				allocation.WastedHosts, want[i].name, want[i].prefix, want[i].wasted)
+		}
+	}
+
+	if plan.FreeAddresses != 62 {
+		t.Errorf("FreeAddresses = %d, want 62", plan.FreeAddresses)
+	}
+
+	wantFree := []netip.Prefix{
+		netip.MustParsePrefix("192.168.1.194/31"),
+		netip.MustParsePrefix("192.168.1.196/30"),
+		netip.MustParsePrefix("192.168.1.200/29"),
+		netip.MustParsePrefix("192.168.1.208/28"),
+		netip.MustParsePrefix("192.168.1.224/27"),
+	}
+
+	if !reflect.DeepEqual(plan.FreeBlocks, wantFree) {
+		t.Errorf("FreeBlocks = %v, want %v", plan.FreeBlocks, wantFree)
+	}
+}
+
+func TestPlanVLSMErrors(t *testing.T) {
+	network := netip.MustParsePrefix("192.168.1.0/24")
+
+	if _, _, err := PlanVLSM(network, []VLSMRequirement{{"A", 200}, {"B", 100}}); !errors.Is(err, ErrDoesNotFit) {
+		t.Errorf("PlanVLSM with too many hosts error = %v, want %v", err, ErrDoesNotFit)
+	}
+
+	if _, _, err := PlanVLSM(network, []VLSMRequirement{{"A", 1000}}); !errors.Is(err, ErrDoesNotFit) {
+		t.Errorf("PlanVLSM with an oversized subnet error = %v, want %v", err, ErrDoesNotFit)
+	}
+
+	if _, _, err := PlanVLSM(network, nil); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("PlanVLSM without requirements error = %v, want %v", err, ErrEmptyInput)
+	}
+
+	if _, _, err := PlanVLSM(netip.MustParsePrefix("2001:db8::/64"), []VLSMRequirement{{"A", 1}}); !errors.Is(err, ErrNotIPv4) {
+		t.Errorf("PlanVLSM with IPv6 error = %v, want %v", err, ErrNotIPv4)
+	}
+}
+
+func FuzzParseVLSMRequirements(f *testing.F) {
+	f.Add("Sales 120 hosts, WAN link 2")
+	f.Add("10;20\n30")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		requirements, err := ParseVLSMRequirements(input)
+		if err != nil {
+			return
+		}
+
+		for _, requirement := range requirements {
+			if requirement.Hosts == 0 || requirement.Name == "" {
+				t.Errorf("ParseVLSMRequirements(%q) returned %+v", input, requirement)
+			}
+		}
+	})
+}
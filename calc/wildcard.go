@@ -0,0 +1,454 @@
+package calc
+
+import (
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WildcardEntry is the address and wildcard (inverse) mask pair of a Cisco
+// ACL entry. A 1 bit in the wildcard means the bit of the address is ignored,
+// so the bits do not have to be contiguous.
+type WildcardEntry struct {
+	Address  netip.Addr
+	Wildcard netip.Addr
+}
+
+func (entry WildcardEntry) String() string {
+	switch ipv4ToUint32(entry.Wildcard) {
+	case 0:
+		return "host " + entry.Address.String()
+	case 0xffffffff:
+		return "any"
+	default:
+		return entry.Address.String() + " " + entry.Wildcard.String()
+	}
+}
+
+// Matches reports whether ipAddress agrees with the entry in every bit the
+// wildcard does not ignore.
+func (entry WildcardEntry) Matches(ipAddress netip.Addr) bool {
+	if !ipAddress.Is4() {
+		return false
+	}
+
+	care := ^ipv4ToUint32(entry.Wildcard)
+
+	return ipv4ToUint32(ipAddress)&care == ipv4ToUint32(entry.Address)&care
+}
+
+func NetworkMaskToWildcard(netMask netip.Addr) (netip.Addr, error) {
+	if !netMask.Is4() {
+		return netip.Addr{}, fmt.Errorf("NetworkMaskToWildcard: %w", ErrNotIPv4)
+	}
+
+	if _, err := maskOnes(netMask); err != nil {
+		return netip.Addr{}, fmt.Errorf("NetworkMaskToWildcard: %w", err)
+	}
+
+	return uint32ToIPv4(^ipv4ToUint32(netMask)), nil
+}
+
+// WildcardToNetworkMask fails for wildcards that ignore non-contiguous bits,
+// which are valid in ACLs but have no network mask equivalent.
+func WildcardToNetworkMask(wildcard netip.Addr) (netip.Addr, error) {
+	if !wildcard.Is4() {
+		return netip.Addr{}, fmt.Errorf("WildcardToNetworkMask: %w", ErrNotIPv4)
+	}
+
+	netMask := uint32ToIPv4(^ipv4ToUint32(wildcard))
+
+	if bit, ok := NonContiguousMaskBit(netMask); ok {
+		return netip.Addr{}, fmt.Errorf("WildcardToNetworkMask: %s matches non-contiguous bits, bit %d is 0 after a 1 bit: %w", wildcard, bit, ErrInvalidMask)
+	}
+
+	return netMask, nil
+}
+
+// maxMinimizedBlocks bounds the number of blocks WildcardEntries splits the
+// prefixes into to look for the fewest entries, and maxCoverTries the number
+// of combinations of candidate entries it tries.
+const (
+	maxMinimizedBlocks = 256
+	maxCoverTries      = 100000
+)
+
+// WildcardEntries returns the fewest wildcard entries that match exactly the
+// addresses of prefixes, which can match prefixes that are not adjacent, such
+// as 10.0.1.0/24 and 10.0.3.0/24, with one entry. The prefixes are aggregated
+// and split into blocks the size of the longest one, and the fewest of the
+// prime implicants of the blocks (Quine-McCluskey) are chosen, so entries
+// may overlap. Beyond maxMinimizedBlocks blocks, or when the choice is not
+// settled within maxCoverTries tries, the result may not be the fewest.
+func WildcardEntries(prefixes []netip.Prefix) ([]WildcardEntry, []string, error) {
+	for _, prefix := range prefixes {
+		if !prefix.Addr().Is4() {
+			return nil, nil, fmt.Errorf("WildcardEntries: %s: %w", prefix, ErrNotIPv4)
+		}
+	}
+
+	aggregated, steps, err := AggregatePrefixes(prefixes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("WildcardEntries: %w", err)
+	}
+
+	blockBits := 0
+	blockCount := uint64(0)
+
+	for _, prefix := range aggregated {
+		if prefix.Bits() > blockBits {
+			blockBits = prefix.Bits()
+		}
+	}
+
+	for _, prefix := range aggregated {
+		blockCount += uint64(1) << (blockBits - prefix.Bits())
+	}
+
+	if blockCount > maxMinimizedBlocks {
+		entries, mergeSteps := mergeWildcardEntries(aggregated)
+		steps = append(steps, fmt.Sprintf("The prefixes split into more than %d /%d blocks, so entries are only merged pairwise.", maxMinimizedBlocks, blockBits))
+
+		return entries, append(steps, mergeSteps...), nil
+	}
+
+	entries, coverSteps, proven := coverWildcardBlocks(aggregated, blockBits)
+
+	if !proven {
+		merged, mergeSteps := mergeWildcardEntries(aggregated)
+
+		if len(merged) < len(entries) {
+			steps = append(steps, "Merging entries pairwise gives fewer entries than the best choice of candidates found.")
+
+			return merged, append(steps, mergeSteps...), nil
+		}
+	}
+
+	return entries, append(steps, coverSteps...), nil
+}
+
+// mergeWildcardEntries turns the prefixes into entries and merges entries
+// with the same wildcard whose addresses differ in a single bit by also
+// ignoring that bit until no two entries merge.
+func mergeWildcardEntries(prefixes []netip.Prefix) ([]WildcardEntry, []string) {
+	var steps []string
+
+	entries := make([]WildcardEntry, 0, len(prefixes))
+
+	for _, prefix := range prefixes {
+		entries = append(entries, WildcardEntry{
+			Address:  prefix.Addr(),
+			Wildcard: uint32ToIPv4(uint32(uint64(1)<<(32-prefix.Bits()) - 1)),
+		})
+	}
+
+	for merged := true; merged; {
+		merged = false
+
+		for i := 0; i < len(entries) && !merged; i++ {
+			for j := i + 1; j < len(entries) && !merged; j++ {
+				if entries[i].Wildcard != entries[j].Wildcard {
+					continue
+				}
+
+				diff := ipv4ToUint32(entries[i].Address) ^ ipv4ToUint32(entries[j].Address)
+				if bits.OnesCount32(diff) != 1 {
+					continue
+				}
+
+				entry := WildcardEntry{
+					Address:  uint32ToIPv4(ipv4ToUint32(entries[i].Address) &^ diff),
+					Wildcard: uint32ToIPv4(ipv4ToUint32(entries[i].Wildcard) | diff),
+				}
+
+				steps = append(steps, fmt.Sprintf("%s and %s differ only in bit %d, so ignoring it as well matches both with %s.",
+					entries[i], entries[j], bits.LeadingZeros32(diff), entry))
+
+				entries[i] = entry
+				entries = append(entries[:j], entries[j+1:]...)
+				merged = true
+			}
+		}
+	}
+
+	return entries, steps
+}
+
+// wildcardCube is an entry as the address bits it matches and the bits it
+// ignores, with the ignored bits of the address cleared.
+type wildcardCube struct {
+	address  uint32
+	wildcard uint32
+}
+
+func (cube wildcardCube) matches(address uint32) bool {
+	return address&^cube.wildcard == cube.address
+}
+
+func (cube wildcardCube) entry() WildcardEntry {
+	return WildcardEntry{Address: uint32ToIPv4(cube.address), Wildcard: uint32ToIPv4(cube.wildcard)}
+}
+
+// coverWildcardBlocks splits prefixes into blocks of blockBits bits, finds the
+// prime implicants of the blocks and picks the fewest of them that match
+// every block, and whether they are proven to be the fewest.
+func coverWildcardBlocks(prefixes []netip.Prefix, blockBits int) ([]WildcardEntry, []string, bool) {
+	blockWildcard := uint32(uint64(1)<<(32-blockBits) - 1)
+
+	var blocks []uint32
+
+	for _, prefix := range prefixes {
+		start := ipv4ToUint32(prefix.Addr())
+
+		for i := uint64(0); i < uint64(1)<<(blockBits-prefix.Bits()); i++ {
+			blocks = append(blocks, start+uint32(i<<(32-blockBits)))
+		}
+	}
+
+	primes := wildcardPrimes(blocks, blockWildcard)
+
+	candidates := make([]string, 0, len(primes))
+	for _, prime := range primes {
+		candidates = append(candidates, prime.entry().String())
+	}
+
+	steps := []string{fmt.Sprintf("Combining the %d /%d blocks that differ in a single bit until no other bit can be ignored gives the candidate entries %s.",
+		len(blocks), blockBits, strings.Join(candidates, ", "))}
+
+	// covers[i] lists the candidates matching blocks[i].
+	covers := make([][]int, len(blocks))
+
+	for i, block := range blocks {
+		for j, prime := range primes {
+			if prime.matches(block) {
+				covers[i] = append(covers[i], j)
+			}
+		}
+	}
+
+	proven := true
+	chosen := make([]bool, len(primes))
+	covered := make([]bool, len(blocks))
+
+	choose := func(prime int) {
+		chosen[prime] = true
+
+		for i, block := range blocks {
+			if primes[prime].matches(block) {
+				covered[i] = true
+			}
+		}
+	}
+
+	for i, block := range blocks {
+		if !covered[i] && len(covers[i]) == 1 {
+			steps = append(steps, fmt.Sprintf("Only %s matches %s, so it is needed.",
+				primes[covers[i][0]].entry(), netip.PrefixFrom(uint32ToIPv4(block), blockBits)))
+			choose(covers[i][0])
+		}
+	}
+
+	var remaining []int
+
+	for i := range blocks {
+		if !covered[i] {
+			remaining = append(remaining, i)
+		}
+	}
+
+	if len(remaining) > 0 {
+		var cover []int
+
+		cover, proven = searchWildcardCover(remaining, covers, primes, blocks)
+
+		picked := make([]string, 0, len(cover))
+		for _, prime := range cover {
+			picked = append(picked, primes[prime].entry().String())
+			choose(prime)
+		}
+
+		if proven {
+			steps = append(steps, fmt.Sprintf("The fewest candidates that also match the other blocks are %s.", strings.Join(picked, ", ")))
+		} else {
+			steps = append(steps, fmt.Sprintf("Trying every combination of candidates for the other blocks takes too long, so the best one found is used: %s.", strings.Join(picked, ", ")))
+		}
+	}
+
+	var entries []WildcardEntry
+
+	for prime, isChosen := range chosen {
+		if isChosen {
+			entries = append(entries, primes[prime].entry())
+		}
+	}
+
+	return entries, steps, proven
+}
+
+// wildcardPrimes combines blocks, each ignoring the bits of blockWildcard,
+// while two of them with the same wildcard differ in a single bit, and
+// returns those that combine with no other, sorted by address.
+func wildcardPrimes(blocks []uint32, blockWildcard uint32) []wildcardCube {
+	var primes []wildcardCube
+
+	current := make([]wildcardCube, 0, len(blocks))
+	for _, block := range blocks {
+		current = append(current, wildcardCube{block, blockWildcard})
+	}
+
+	for len(current) > 0 {
+		present := make(map[wildcardCube]bool, len(current))
+		for _, cube := range current {
+			present[cube] = true
+		}
+
+		combined := make(map[wildcardCube]bool)
+		seen := make(map[wildcardCube]bool)
+
+		var next []wildcardCube
+
+		for _, cube := range current {
+			for bit := uint32(1); bit != 0; bit <<= 1 {
+				if cube.wildcard&bit != 0 || cube.address&bit != 0 {
+					continue
+				}
+
+				other := wildcardCube{cube.address | bit, cube.wildcard}
+				if !present[other] {
+					continue
+				}
+
+				combined[cube], combined[other] = true, true
+
+				merged := wildcardCube{cube.address, cube.wildcard | bit}
+				if !seen[merged] {
+					seen[merged] = true
+					next = append(next, merged)
+				}
+			}
+		}
+
+		for _, cube := range current {
+			if !combined[cube] {
+				primes = append(primes, cube)
+			}
+		}
+
+		current = next
+	}
+
+	sort.Slice(primes, func(i, j int) bool {
+		if primes[i].address != primes[j].address {
+			return primes[i].address < primes[j].address
+		}
+
+		return primes[i].wildcard < primes[j].wildcard
+	})
+
+	return primes
+}
+
+// searchWildcardCover returns the fewest primes that match the blocks of
+// remaining, and whether they are proven to be the fewest. It branches on the
+// block with the fewest candidates, trying the candidates that match the most
+// blocks first, and gives up after maxCoverTries tries, keeping the best
+// cover found.
+func searchWildcardCover(remaining []int, covers [][]int, primes []wildcardCube, blocks []uint32) ([]int, bool) {
+	var best []int
+	tries := 0
+
+	var search func(uncovered []int, picked []int)
+
+	search = func(uncovered []int, picked []int) {
+		if len(uncovered) == 0 {
+			if best == nil || len(picked) < len(best) {
+				best = append([]int(nil), picked...)
+			}
+
+			return
+		}
+
+		if tries >= maxCoverTries {
+			return
+		}
+
+		tries++
+
+		hardest := uncovered[0]
+		for _, block := range uncovered {
+			if len(covers[block]) < len(covers[hardest]) {
+				hardest = block
+			}
+		}
+
+		rests := make([][]int, len(primes))
+		widest := 0
+
+		for _, prime := range covers[hardest] {
+			for _, block := range uncovered {
+				if !primes[prime].matches(blocks[block]) {
+					rests[prime] = append(rests[prime], block)
+				}
+			}
+
+			if matched := len(uncovered) - len(rests[prime]); matched > widest {
+				widest = matched
+			}
+		}
+
+		// No candidate matches more than widest of the uncovered blocks, so
+		// at least this many more are needed.
+		if best != nil && len(picked)+(len(uncovered)+widest-1)/widest >= len(best) {
+			return
+		}
+
+		order := append([]int(nil), covers[hardest]...)
+		sort.SliceStable(order, func(i, j int) bool {
+			return len(rests[order[i]]) < len(rests[order[j]])
+		})
+
+		for _, prime := range order {
+			search(rests[prime], append(picked, prime))
+		}
+	}
+
+	search(remaining, nil)
+
+	sort.Ints(best)
+
+	return best, tries < maxCoverTries
+}
+
+// FormatACL formats entries as a standard ACL. A numeric name gives
+// "access-list" lines and any other name a named "ip access-list standard"
+// block.
+func FormatACL(entries []WildcardEntry, name string, action string) (string, error) {
+	if action != "permit" && action != "deny" {
+		return "", fmt.Errorf("FormatACL: %q: %w", action, ErrUnknownAction)
+	}
+
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return "", fmt.Errorf("FormatACL: %q: %w", name, ErrInvalidACLName)
+	}
+
+	var buf strings.Builder
+
+	if _, err := strconv.Atoi(name); err == nil {
+		for _, entry := range entries {
+			fmt.Fprintf(&buf, "access-list %s %s %s\n", name, action, entry)
+		}
+
+		return buf.String(), nil
+	}
+
+	fmt.Fprintf(&buf, "ip access-list standard %s\n", name)
+
+	for _, entry := range entries {
+		fmt.Fprintf(&buf, " %s %s\n", action, entry)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,225 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"testing"
+	"testing/quick"
+)
+
+func TestNetworkMaskToWildcard(t *testing.T) {
+	tests := []struct {
+		netMask string
+		want    string
+		wantErr error
+	}{
+		{"255.255.255.0", "0.0.0.255", nil},
+		{"255.255.240.0", "0.0.15.255", nil},
+		{"0.0.0.0", "255.255.255.255", nil},
+		{"255.255.255.255", "0.0.0.0", nil},
+		{"255.0.255.0", "", ErrInvalidMask},
+		{"ffff::", "", ErrNotIPv4},
+	}
+
+	for _, test := range tests {
+		got, err := NetworkMaskToWildcard(netip.MustParseAddr(test.netMask))
+		if !errors.Is(err, test.wantErr) || (err == nil && got.String() != test.want) {
+			t.Errorf("NetworkMaskToWildcard(%s) = %s, %v, want %s, %v", test.netMask, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestWildcardToNetworkMask(t *testing.T) {
+	tests := []struct {
+		wildcard string
+		want     string
+		wantErr  error
+	}{
+		{"0.0.0.255", "255.255.255.0", nil},
+		{"0.0.3.255", "255.255.252.0", nil},
+		{"0.0.2.255", "", ErrInvalidMask},
+		{"255.255.255.0", "", ErrInvalidMask},
+	}
+
+	for _, test := range tests {
+		got, err := WildcardToNetworkMask(netip.MustParseAddr(test.wildcard))
+		if !errors.Is(err, test.wantErr) || (err == nil && got.String() != test.want) {
+			t.Errorf("WildcardToNetworkMask(%s) = %s, %v, want %s, %v", test.wildcard, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestWildcardEntries(t *testing.T) {
+	tests := []struct {
+		input []string
+		want  string
+	}{
+		{[]string{"10.0.0.0/24"}, "[10.0.0.0 0.0.0.255]"},
+		{[]string{"10.0.0.0/24", "10.0.1.0/24"}, "[10.0.0.0 0.0.1.255]"},
+		{[]string{"10.0.1.0/24", "10.0.3.0/24"}, "[10.0.1.0 0.0.2.255]"},
+		{[]string{"10.1.5.0/24", "10.2.5.0/24", "10.3.5.0/24", "10.0.5.0/24"}, "[10.0.5.0 0.3.0.255]"},
+		{[]string{"192.168.1.7/32"}, "[host 192.168.1.7]"},
+		{[]string{"0.0.0.0/0"}, "[any]"},
+		{[]string{"10.0.1.0/24", "10.0.2.0/24"}, "[10.0.1.0 0.0.0.255 10.0.2.0 0.0.0.255]"},
+		{[]string{"10.1.0.1/32", "10.1.0.2/31", "10.1.0.4/31", "10.1.0.6/32"}, "[10.1.0.1 0.0.0.2 10.1.0.2 0.0.0.4 10.1.0.4 0.0.0.1]"},
+		{[]string{"10.0.0.0/24", "10.0.1.0/32", "10.0.3.0/32"}, "[10.0.0.0 0.0.0.255 10.0.1.0 0.0.2.0]"},
+	}
+
+	for _, test := range tests {
+		got, _, err := WildcardEntries(parsePrefixes(test.input...))
+		if err != nil || fmt.Sprint(got) != test.want {
+			t.Errorf("WildcardEntries(%v) = %v, %v, want %s", test.input, got, err, test.want)
+		}
+	}
+
+	if _, _, err := WildcardEntries(parsePrefixes("2001:db8::/32")); !errors.Is(err, ErrNotIPv4) {
+		t.Errorf("WildcardEntries(IPv6) error = %v, want %v", err, ErrNotIPv4)
+	}
+}
+
+func TestWildcardEntriesMatchExactly(t *testing.T) {
+	matchesExactly := func(hosts []uint8) bool {
+		if len(hosts) == 0 {
+			return true
+		}
+
+		prefixes := make([]netip.Prefix, 0, len(hosts))
+		wanted := make(map[uint8]bool)
+
+		for _, host := range hosts {
+			prefixes = append(prefixes, netip.PrefixFrom(uint32ToIPv4(0x0a000000|uint32(host)), 32))
+			wanted[host] = true
+		}
+
+		entries, _, err := WildcardEntries(prefixes)
+		if err != nil {
+			return false
+		}
+
+		for host := 0; host < 256; host++ {
+			matched := false
+
+			for _, entry := range entries {
+				matched = matched || entry.Matches(uint32ToIPv4(0x0a000000|uint32(host)))
+			}
+
+			if matched != wanted[uint8(host)] {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(matchesExactly, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWildcardEntriesFewest(t *testing.T) {
+	// fewest counts by brute force the fewest entries matching exactly the
+	// hosts of 10.0.0.0/28 in wanted, one bit per host.
+	fewest := func(wanted uint16) int {
+		var cubes []uint16
+
+		for address := 0; address < 16; address++ {
+			for wildcard := 0; wildcard < 16; wildcard++ {
+				if address&wildcard != 0 {
+					continue
+				}
+
+				var cube uint16
+				for host := 0; host < 16; host++ {
+					if host&^wildcard == address {
+						cube |= 1 << host
+					}
+				}
+
+				if cube&^wanted == 0 {
+					cubes = append(cubes, cube)
+				}
+			}
+		}
+
+		memo := map[uint16]int{0: 0}
+
+		var cover func(uncovered uint16) int
+
+		cover = func(uncovered uint16) int {
+			if count, ok := memo[uncovered]; ok {
+				return count
+			}
+
+			lowest := uncovered & -uncovered
+			best := 16
+
+			for _, cube := range cubes {
+				if cube&lowest != 0 {
+					if count := 1 + cover(uncovered&^cube); count < best {
+						best = count
+					}
+				}
+			}
+
+			memo[uncovered] = best
+
+			return best
+		}
+
+		return cover(wanted)
+	}
+
+	isFewest := func(wanted uint16) bool {
+		if wanted == 0 {
+			return true
+		}
+
+		var prefixes []netip.Prefix
+
+		for host := 0; host < 16; host++ {
+			if wanted&(1<<host) != 0 {
+				prefixes = append(prefixes, netip.PrefixFrom(uint32ToIPv4(0x0a000000|uint32(host)), 32))
+			}
+		}
+
+		entries, _, err := WildcardEntries(prefixes)
+
+		return err == nil && len(entries) == fewest(wanted)
+	}
+
+	if err := quick.Check(isFewest, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFormatACL(t *testing.T) {
+	entries := []WildcardEntry{
+		{netip.MustParseAddr("10.0.1.0"), netip.MustParseAddr("0.0.2.255")},
+		{netip.MustParseAddr("192.168.1.7"), netip.MustParseAddr("0.0.0.0")},
+	}
+
+	tests := []struct {
+		name   string
+		action string
+		want   string
+	}{
+		{"10", "permit", "access-list 10 permit 10.0.1.0 0.0.2.255\naccess-list 10 permit host 192.168.1.7\n"},
+		{"MGMT", "deny", "ip access-list standard MGMT\n deny 10.0.1.0 0.0.2.255\n deny host 192.168.1.7\n"},
+	}
+
+	for _, test := range tests {
+		got, err := FormatACL(entries, test.name, test.action)
+		if err != nil || got != test.want {
+			t.Errorf("FormatACL(%s, %s) = %q, %v, want %q", test.name, test.action, got, err, test.want)
+		}
+	}
+
+	if _, err := FormatACL(entries, "10", "allow"); !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("FormatACL(allow) error = %v, want %v", err, ErrUnknownAction)
+	}
+
+	if _, err := FormatACL(entries, "MY ACL", "permit"); !errors.Is(err, ErrInvalidACLName) {
+		t.Errorf("FormatACL(MY ACL) error = %v, want %v", err, ErrInvalidACLName)
+	}
+}
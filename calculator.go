@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// Calculator is a panel of the GUI. A calculator registers its constructor
+// with RegisterCalculator from an init function, usually in its own file,
+// and then appears in the sidebar under its category and contributes its
+// commands to the command line.
+type Calculator interface {
+	// Title is the heading shown above the panel.
+	Title() string
+	Category() string
+	// Height is the height of the panel in the scrollable list of its
+	// category. The panel is laid out to fill it exactly.
+	Height() unit.Dp
+	Layout(th *material.Theme, gtx layout.Context) layout.Dimensions
+	// State returns the contents of the inputs, keyed by a name that is
+	// stable across versions, and SetState restores them.
+	State() map[string]string
+	SetState(state map[string]string)
+	// Commands returns the netcalc subcommands of the calculator, if any.
+	// No two calculators may define a command of the same name.
+	Commands() map[string]cliCommand
+}
+
+const (
+	categoryAddressing     = "Addressing"
+	categoryMasks          = "Masks"
+	categorySubnetting     = "Subnetting"
+	categoryRouting        = "Routing and filtering"
+	categoryNumberBases    = "Number bases"
+	categoryErrorDetection = "Error detection"
+)
+
+// calculatorCategories is the order of the built-in categories in the
+// sidebar. Other categories follow in the order they are first registered.
+var calculatorCategories = []string{
+	categoryAddressing,
+	categoryMasks,
+	categorySubnetting,
+	categoryRouting,
+	categoryNumberBases,
+	categoryErrorDetection,
+}
+
+var (
+	calculatorConstructors []func() Calculator
+	commandOwners          = map[string]string{}
+)
+
+// RegisterCalculator adds a calculator to every Application created from
+// then on, and adds its commands to the command line.
+func RegisterCalculator(newCalculator func() Calculator) {
+	calculator := newCalculator()
+	registerCommands(calculator.Title(), calculator.Commands())
+
+	calculatorConstructors = append(calculatorConstructors, newCalculator)
+}
+
+// registerCommands adds commands to the command line on behalf of owner. It
+// panics if another owner already registered a command of the same name.
+func registerCommands(owner string, commands map[string]cliCommand) {
+	for name, command := range commands {
+		if other, ok := commandOwners[name]; ok {
+			panic(fmt.Sprintf("registerCommands: command %q of %q is already registered by %q", name, owner, other))
+		}
+
+		commandOwners[name] = owner
+		cliCommands[name] = command
+	}
+}
+
+// newCalculators creates one of every registered calculator, grouped by
+// category in sidebar order.
+func newCalculators() []category {
+	categories := make([]category, 0, len(calculatorCategories))
+	for _, name := range calculatorCategories {
+		categories = append(categories, category{Name: name})
+	}
+
+	for _, newCalculator := range calculatorConstructors {
+		calculator := newCalculator()
+
+		i := 0
+		for i < len(categories) && categories[i].Name != calculator.Category() {
+			i++
+		}
+
+		if i == len(categories) {
+			categories = append(categories, category{Name: calculator.Category()})
+		}
+
+		categories[i].Calculators = append(categories[i].Calculators, calculator)
+	}
+
+	nonEmpty := categories[:0]
+
+	for _, category := range categories {
+		if len(category.Calculators) > 0 {
+			nonEmpty = append(nonEmpty, category)
+		}
+	}
+
+	return nonEmpty
+}
+
+// inputState implements Calculator.State for calculators whose inputs are
+// exported Field, widget.Bool, widget.Enum and []widget.Bool struct fields
+// with a state tag, such as `state:"Dec"`. The tag is the key of the input
+// in the saved state, so it must not change when the field is renamed.
+// Fields without a tag are not saved.
+func inputState(calculator interface{}) map[string]string {
+	state := make(map[string]string)
+	value := reflect.ValueOf(calculator).Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		key, ok := stateKey(value.Type().Field(i))
+		if !ok {
+			continue
+		}
+
+		switch input := value.Field(i).Addr().Interface().(type) {
+		case *Field:
+			state[key] = input.Text()
+		case *widget.Bool:
+			state[key] = strconv.FormatBool(input.Value)
+		case *widget.Enum:
+			state[key] = input.Value
+		case *[]widget.Bool:
+			values := make([]string, 0, len(*input))
+			for _, b := range *input {
+				values = append(values, strconv.FormatBool(b.Value))
+			}
+
+			state[key] = strings.Join(values, ",")
+		}
+	}
+
+	return state
+}
+
+// setInputState implements Calculator.SetState for the inputs inputState
+// saves. Fields are restored as changed, so that results are recomputed on
+// the next frame, and keys that are missing or unknown are ignored.
+func setInputState(calculator interface{}, state map[string]string) {
+	value := reflect.ValueOf(calculator).Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		key, ok := stateKey(value.Type().Field(i))
+		if !ok {
+			continue
+		}
+
+		text, ok := state[key]
+		if !ok {
+			continue
+		}
+
+		switch input := value.Field(i).Addr().Interface().(type) {
+		case *Field:
+			input.Restore(text)
+		case *widget.Bool:
+			input.Value = text == "true"
+		case *widget.Enum:
+			input.Value = text
+		case *[]widget.Bool:
+			if text == "" {
+				continue
+			}
+
+			values := strings.Split(text, ",")
+			*input = make([]widget.Bool, len(values))
+
+			for j, v := range values {
+				(*input)[j].Value = v == "true"
+			}
+		}
+	}
+}
+
+func stateKey(field reflect.StructField) (string, bool) {
+	key := field.Tag.Get("state")
+
+	return key, key != "" && field.IsExported()
+}
+
+// calculatorOutputs returns the lines of the exported StepList fields of a
+// calculator, which is how the built-in calculators show their results.
+func calculatorOutputs(calculator interface{}) []string {
+	value := reflect.ValueOf(calculator)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	value = value.Elem()
+
+	var lines []string
+
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+
+		if steps, ok := value.Field(i).Addr().Interface().(*StepList); ok {
+			lines = append(lines, steps.Steps...)
+		}
+	}
+
+	return lines
+}
+
+// hasInvalidInput reports whether any exported Field of a calculator is
+// marked invalid, so that the calculation is not complete.
+func hasInvalidInput(calculator interface{}) bool {
+	value := reflect.ValueOf(calculator)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	value = value.Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+
+		if field, ok := value.Field(i).Addr().Interface().(*Field); ok && field.Invalid && field.Text() != "" {
+			return true
+		}
+	}
+
+	return false
+}
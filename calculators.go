@@ -0,0 +1,785 @@
+package main
+
+import (
+	"github.com/KhangBBBB/netcalc/calc"
+
+	"gioui.org/unit"
+)
+
+// The built-in calculators, in sidebar order within each category.
+func init() {
+	RegisterCalculator(func() Calculator { return &IPv4DecHexBinConverter{} })
+	RegisterCalculator(func() Calculator { return &IPv6HexBinConverter{} })
+	RegisterCalculator(func() Calculator { return &IPInfoChecker{} })
+	RegisterCalculator(func() Calculator { return &RangeCIDRConverter{} })
+	RegisterCalculator(func() Calculator { return &NetMaskCIDRSlashConverter{} })
+	RegisterCalculator(func() Calculator { return &IPv6MaskCIDRSlashConverter{} })
+	RegisterCalculator(func() Calculator {
+		generator := &WildcardACLGenerator{}
+		generator.Name.SetText("10")
+		generator.Action.Value = "permit"
+
+		return generator
+	})
+	RegisterCalculator(func() Calculator { return &NetAddrFinder{} })
+	RegisterCalculator(func() Calculator {
+		visualizer := &SubnetVisualizer{}
+		visualizer.Network.SetText("192.168.1.10/24")
+		visualizer.SubnetPrefix.SetText("/26")
+
+		return visualizer
+	})
+	RegisterCalculator(func() Calculator { return &IPv6NetAddrFinder{} })
+	RegisterCalculator(func() Calculator {
+		subnetter := &Subnetter{}
+		subnetter.Mode.Value = subnetterBySubnetCount
+
+		return subnetter
+	})
+	RegisterCalculator(func() Calculator { return &VLSMPlanner{} })
+	RegisterCalculator(func() Calculator { return &PrefixAggregator{} })
+	RegisterCalculator(func() Calculator {
+		generator := &BogonFilterGenerator{}
+		generator.Name.SetText("BOGONS")
+		generator.Format.Value = calc.BogonFilterFormats[0]
+		generator.setDefaultCategories()
+		generator.generate()
+
+		return generator
+	})
+	RegisterCalculator(func() Calculator {
+		conv := &DecHexBinConverter{}
+		conv.Radix.SetText("36")
+		conv.Width.Value = converterArbitraryWidth
+
+		return conv
+	})
+	RegisterCalculator(func() Calculator {
+		bench := &BitwiseWorkbench{}
+		bench.Operator.Value = calc.OpAND.String()
+		bench.Width.Value = "32"
+
+		return bench
+	})
+	RegisterCalculator(func() Calculator {
+		calculator := &ChecksumCalculator{}
+		calculator.Mode.Value = checksumCompute
+
+		return calculator
+	})
+	RegisterCalculator(func() Calculator {
+		calculator := &CRCCalculator{}
+		calculator.Preset.Value = calc.CRCPresets[0].Name
+
+		return calculator
+	})
+
+	// The paste box and the history are not calculators, but their commands
+	// are registered the same way.
+	registerCommands("Paste anything", new(PasteInspector).Commands())
+	registerCommands("History", new(HistoryView).Commands())
+}
+
+func (conv *IPv4DecHexBinConverter) Title() string {
+	return "Convert between IPv4 dot decimal, hexadecimal, and binary formats"
+}
+
+func (conv *IPv4DecHexBinConverter) Category() string {
+	return categoryAddressing
+}
+
+func (conv *IPv4DecHexBinConverter) Height() unit.Dp {
+	return 32
+}
+
+func (conv *IPv4DecHexBinConverter) State() map[string]string {
+	return inputState(conv)
+}
+
+func (conv *IPv4DecHexBinConverter) SetState(state map[string]string) {
+	setInputState(conv, state)
+}
+
+func (conv *IPv4DecHexBinConverter) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"ip2hex": {
+			Usage:       "ip2hex <ipv4>",
+			Description: "convert IPv4 dot decimal to hexadecimal",
+			Run: stringCommand(1, func(args []string) (string, error) {
+				ipv4, err := calc.ParseIPv4(args[0])
+				if err != nil {
+					return "", err
+				}
+
+				return calc.IPv4ToHexFormat(ipv4)
+			}),
+		},
+		"ip2bin": {
+			Usage:       "ip2bin <ipv4>",
+			Description: "convert IPv4 dot decimal to binary",
+			Run: stringCommand(1, func(args []string) (string, error) {
+				ipv4, err := calc.ParseIPv4(args[0])
+				if err != nil {
+					return "", err
+				}
+
+				return calc.IPv4ToBinFormat(ipv4)
+			}),
+		},
+		"hex2ip": {
+			Usage:       "hex2ip <hex>",
+			Description: "convert hexadecimal to IPv4 dot decimal",
+			Run:         addrCommand(calc.HexToIPv4Format),
+		},
+		"bin2ip": {
+			Usage:       "bin2ip <bin>",
+			Description: "convert binary to IPv4 dot decimal",
+			Run:         addrCommand(calc.BinToIPv4Format),
+		},
+	}
+}
+
+func (conv *IPv6HexBinConverter) Title() string {
+	return "Convert between IPv6 address, hexadecimal, and binary formats"
+}
+
+func (conv *IPv6HexBinConverter) Category() string {
+	return categoryAddressing
+}
+
+func (conv *IPv6HexBinConverter) Height() unit.Dp {
+	return 32
+}
+
+func (conv *IPv6HexBinConverter) State() map[string]string {
+	return inputState(conv)
+}
+
+func (conv *IPv6HexBinConverter) SetState(state map[string]string) {
+	setInputState(conv, state)
+}
+
+func (conv *IPv6HexBinConverter) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"ip6hex": {
+			Usage:       "ip6hex <ipv6>",
+			Description: "convert an IPv6 address to hexadecimal",
+			Run: stringCommand(1, func(args []string) (string, error) {
+				ipv6, err := calc.ParseIPv6(args[0])
+				if err != nil {
+					return "", err
+				}
+
+				return calc.IPv6ToHexFormat(ipv6)
+			}),
+		},
+		"ip6bin": {
+			Usage:       "ip6bin <ipv6>",
+			Description: "convert an IPv6 address to binary",
+			Run: stringCommand(1, func(args []string) (string, error) {
+				ipv6, err := calc.ParseIPv6(args[0])
+				if err != nil {
+					return "", err
+				}
+
+				return calc.IPv6ToBinFormat(ipv6)
+			}),
+		},
+		"hex2ip6": {
+			Usage:       "hex2ip6 <hex>",
+			Description: "convert hexadecimal to an IPv6 address",
+			Run:         addrCommand(calc.HexToIPv6Format),
+		},
+		"bin2ip6": {
+			Usage:       "bin2ip6 <bin>",
+			Description: "convert binary to an IPv6 address",
+			Run:         addrCommand(calc.BinToIPv6Format),
+		},
+	}
+}
+
+func (checker *IPInfoChecker) Title() string {
+	return "Look up an IP address in the IANA special-purpose registries (RFC 6890)"
+}
+
+func (checker *IPInfoChecker) Category() string {
+	return categoryAddressing
+}
+
+func (checker *IPInfoChecker) Height() unit.Dp {
+	return 140
+}
+
+func (checker *IPInfoChecker) State() map[string]string {
+	return inputState(checker)
+}
+
+func (checker *IPInfoChecker) SetState(state map[string]string) {
+	setInputState(checker, state)
+}
+
+func (checker *IPInfoChecker) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"classify": {
+			Usage:       "classify <ip>",
+			Description: "report whether an IP address is private, loopback, link-local unicast or multicast",
+			Run:         runClassifyCommand,
+		},
+		"special": {
+			Usage:       "special <ip>",
+			Description: "look up an IP address in the IANA special-purpose address registries (RFC 6890)",
+			Run:         runSpecialCommand,
+		},
+	}
+}
+
+func (conv *RangeCIDRConverter) Title() string {
+	return "Convert between an address range and CIDR prefixes"
+}
+
+func (conv *RangeCIDRConverter) Category() string {
+	return categoryAddressing
+}
+
+func (conv *RangeCIDRConverter) Height() unit.Dp {
+	return 200
+}
+
+func (conv *RangeCIDRConverter) State() map[string]string {
+	return inputState(conv)
+}
+
+func (conv *RangeCIDRConverter) SetState(state map[string]string) {
+	setInputState(conv, state)
+}
+
+func (conv *RangeCIDRConverter) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"range2cidr": {
+			Usage:       "range2cidr <start>-<end> | <start> <end>",
+			Description: "split an IPv4 or IPv6 address range into the fewest CIDR prefixes",
+			Run:         runRangeToCIDRCommand,
+		},
+		"cidr2range": {
+			Usage:       "cidr2range <prefix>",
+			Description: "show the first and last address of a CIDR prefix",
+			Run:         runCIDRToRangeCommand,
+		},
+	}
+}
+
+func (conv *NetMaskCIDRSlashConverter) Title() string {
+	return "Convert between network mask and CIDR slash value"
+}
+
+func (conv *NetMaskCIDRSlashConverter) Category() string {
+	return categoryMasks
+}
+
+func (conv *NetMaskCIDRSlashConverter) Height() unit.Dp {
+	return 80
+}
+
+func (conv *NetMaskCIDRSlashConverter) State() map[string]string {
+	return inputState(conv)
+}
+
+func (conv *NetMaskCIDRSlashConverter) SetState(state map[string]string) {
+	setInputState(conv, state)
+}
+
+func (conv *NetMaskCIDRSlashConverter) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"mask2cidr": {
+			Usage:       "mask2cidr <mask>",
+			Description: "convert an IPv4 or IPv6 network mask to a CIDR slash value",
+			Run:         stringCommand(1, func(args []string) (string, error) { return parseNetworkMask(args[0]) }),
+		},
+		"cidr2mask": {
+			Usage:       "cidr2mask <slash>",
+			Description: "convert a CIDR slash value to an IPv4 network mask",
+			Run: stringCommand(1, func(args []string) (string, error) {
+				return parseCIDRSlashValue(args[0], 32)
+			}),
+		},
+	}
+}
+
+func (conv *IPv6MaskCIDRSlashConverter) Title() string {
+	return "Convert between IPv6 mask and CIDR slash value"
+}
+
+func (conv *IPv6MaskCIDRSlashConverter) Category() string {
+	return categoryMasks
+}
+
+func (conv *IPv6MaskCIDRSlashConverter) Height() unit.Dp {
+	return 80
+}
+
+func (conv *IPv6MaskCIDRSlashConverter) State() map[string]string {
+	return inputState(conv)
+}
+
+func (conv *IPv6MaskCIDRSlashConverter) SetState(state map[string]string) {
+	setInputState(conv, state)
+}
+
+func (conv *IPv6MaskCIDRSlashConverter) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"cidr2mask6": {
+			Usage:       "cidr2mask6 <slash>",
+			Description: "convert a CIDR slash value to an IPv6 mask",
+			Run: stringCommand(1, func(args []string) (string, error) {
+				return parseCIDRSlashValue(args[0], 128)
+			}),
+		},
+	}
+}
+
+func (generator *WildcardACLGenerator) Title() string {
+	return "Convert between network mask and wildcard mask, and generate ACL entries"
+}
+
+func (generator *WildcardACLGenerator) Category() string {
+	return categoryMasks
+}
+
+func (generator *WildcardACLGenerator) Height() unit.Dp {
+	return 200
+}
+
+func (generator *WildcardACLGenerator) State() map[string]string {
+	return inputState(generator)
+}
+
+func (generator *WildcardACLGenerator) SetState(state map[string]string) {
+	setInputState(generator, state)
+}
+
+func (generator *WildcardACLGenerator) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"mask2wildcard": {
+			Usage:       "mask2wildcard <mask>",
+			Description: "convert an IPv4 network mask to a wildcard mask",
+			Run:         runMaskToWildcardCommand,
+		},
+		"wildcard2mask": {
+			Usage:       "wildcard2mask <wildcard>",
+			Description: "convert a contiguous wildcard mask to a network mask",
+			Run:         runWildcardToMaskCommand,
+		},
+		"acl": {
+			Usage:       "acl <name> permit|deny <target> ...",
+			Description: "generate the fewest standard ACL entries for prefixes, hosts and ranges",
+			Run:         runACLCommand,
+		},
+	}
+}
+
+func (finder *NetAddrFinder) Title() string {
+	return "Compute network details from host IP address and network mask"
+}
+
+func (finder *NetAddrFinder) Category() string {
+	return categorySubnetting
+}
+
+func (finder *NetAddrFinder) Height() unit.Dp {
+	return 200
+}
+
+func (finder *NetAddrFinder) State() map[string]string {
+	return inputState(finder)
+}
+
+func (finder *NetAddrFinder) SetState(state map[string]string) {
+	setInputState(finder, state)
+}
+
+func (finder *NetAddrFinder) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"netaddr": {
+			Usage:       "netaddr <host-ip/prefix> | <host-ip> <mask>",
+			Description: "compute the network address of an IPv4 or IPv6 host",
+			Run:         stringCommand(-1, runNetAddrCommand),
+		},
+		"bitmask": {
+			Usage:       "bitmask <ip> <mask>",
+			Description: "AND an IPv4 or IPv6 address with an arbitrary, possibly non-contiguous, mask",
+			Run:         runBitmaskCommand,
+		},
+		"subnetinfo": {
+			Usage:       "subnetinfo <ipv4/prefix> | <ipv4> <mask>",
+			Description: "show broadcast, host range, host counts, wildcard mask and class of an IPv4 network",
+			Run:         runSubnetInfoCommand,
+		},
+	}
+}
+
+func (visualizer *SubnetVisualizer) Title() string {
+	return "Visualize network, subnet, and host bits (drag the red marker)"
+}
+
+func (visualizer *SubnetVisualizer) Category() string {
+	return categorySubnetting
+}
+
+func (visualizer *SubnetVisualizer) Height() unit.Dp {
+	return 200
+}
+
+func (visualizer *SubnetVisualizer) State() map[string]string {
+	return inputState(visualizer)
+}
+
+func (visualizer *SubnetVisualizer) SetState(state map[string]string) {
+	setInputState(visualizer, state)
+}
+
+func (visualizer *SubnetVisualizer) Commands() map[string]cliCommand {
+	return nil
+}
+
+func (finder *IPv6NetAddrFinder) Title() string {
+	return "Compute IPv6 network address from host IP address and prefix"
+}
+
+func (finder *IPv6NetAddrFinder) Category() string {
+	return categorySubnetting
+}
+
+func (finder *IPv6NetAddrFinder) Height() unit.Dp {
+	return 32
+}
+
+func (finder *IPv6NetAddrFinder) State() map[string]string {
+	return inputState(finder)
+}
+
+func (finder *IPv6NetAddrFinder) SetState(state map[string]string) {
+	setInputState(finder, state)
+}
+
+func (finder *IPv6NetAddrFinder) Commands() map[string]cliCommand {
+	return nil
+}
+
+func (subnetter *Subnetter) Title() string {
+	return "Split a network into equal subnets"
+}
+
+func (subnetter *Subnetter) Category() string {
+	return categorySubnetting
+}
+
+func (subnetter *Subnetter) Height() unit.Dp {
+	return 200
+}
+
+func (subnetter *Subnetter) State() map[string]string {
+	return inputState(subnetter)
+}
+
+func (subnetter *Subnetter) SetState(state map[string]string) {
+	setInputState(subnetter, state)
+}
+
+func (subnetter *Subnetter) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"subnet": {
+			Usage:       "subnet <network/prefix> subnets|hosts <count>",
+			Description: "split a network into equal subnets",
+			Run:         runSubnetCommand,
+		},
+	}
+}
+
+func (planner *VLSMPlanner) Title() string {
+	return "Allocate variable length subnets (VLSM) from a network"
+}
+
+func (planner *VLSMPlanner) Category() string {
+	return categorySubnetting
+}
+
+func (planner *VLSMPlanner) Height() unit.Dp {
+	return 200
+}
+
+func (planner *VLSMPlanner) State() map[string]string {
+	return inputState(planner)
+}
+
+func (planner *VLSMPlanner) SetState(state map[string]string) {
+	setInputState(planner, state)
+}
+
+func (planner *VLSMPlanner) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"vlsm": {
+			Usage:       "vlsm <network/prefix> \"<name> <hosts>, ...\"",
+			Description: "allocate variable length subnets from a network",
+			Run:         runVLSMCommand,
+		},
+	}
+}
+
+func (aggregator *PrefixAggregator) Title() string {
+	return "Aggregate prefixes or find their supernet"
+}
+
+func (aggregator *PrefixAggregator) Category() string {
+	return categoryRouting
+}
+
+func (aggregator *PrefixAggregator) Height() unit.Dp {
+	return 200
+}
+
+func (aggregator *PrefixAggregator) State() map[string]string {
+	return inputState(aggregator)
+}
+
+func (aggregator *PrefixAggregator) SetState(state map[string]string) {
+	setInputState(aggregator, state)
+}
+
+func (aggregator *PrefixAggregator) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"aggregate": {
+			Usage:       "aggregate <prefix> ...",
+			Description: "merge prefixes into the fewest prefixes covering the same addresses",
+			Run:         runAggregateCommand,
+		},
+		"supernet": {
+			Usage:       "supernet <prefix> ...",
+			Description: "find the smallest prefix covering all prefixes and the extra space it adds",
+			Run:         runSupernetCommand,
+		},
+	}
+}
+
+func (generator *BogonFilterGenerator) Title() string {
+	return "Generate a bogon filter"
+}
+
+func (generator *BogonFilterGenerator) Category() string {
+	return categoryRouting
+}
+
+func (generator *BogonFilterGenerator) Height() unit.Dp {
+	return 260
+}
+
+func (generator *BogonFilterGenerator) State() map[string]string {
+	return inputState(generator)
+}
+
+// SetState falls back to the default categories if the state has a different
+// number of them, as saved by a version with other categories.
+func (generator *BogonFilterGenerator) SetState(state map[string]string) {
+	setInputState(generator, state)
+
+	if len(generator.Categories) != len(calc.BogonCategories) {
+		generator.setDefaultCategories()
+	}
+
+	generator.generate()
+}
+
+func (generator *BogonFilterGenerator) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"bogons": {
+			Usage:       "bogons <format> [category ...]",
+			Description: "export a bogon filter as cisco, juniper, nftables or text; categories default to all but multicast",
+			Run:         runBogonsCommand,
+		},
+	}
+}
+
+func (conv *DecHexBinConverter) Title() string {
+	return "Convert between decimal, hexadecimal, octal, binary, and any base from 2 to 36"
+}
+
+func (conv *DecHexBinConverter) Category() string {
+	return categoryNumberBases
+}
+
+func (conv *DecHexBinConverter) Height() unit.Dp {
+	return 140
+}
+
+func (conv *DecHexBinConverter) State() map[string]string {
+	return inputState(conv)
+}
+
+func (conv *DecHexBinConverter) SetState(state map[string]string) {
+	setInputState(conv, state)
+	conv.restore()
+}
+
+func (conv *DecHexBinConverter) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"dec2hex": {
+			Usage:       "dec2hex <decimal>",
+			Description: "convert decimal to hexadecimal",
+			Run:         numberCommand(10, 16),
+		},
+		"dec2bin": {
+			Usage:       "dec2bin <decimal>",
+			Description: "convert decimal to binary",
+			Run:         numberCommand(10, 2),
+		},
+		"dec2oct": {
+			Usage:       "dec2oct <decimal>",
+			Description: "convert decimal to octal",
+			Run:         numberCommand(10, 8),
+		},
+		"hex2dec": {
+			Usage:       "hex2dec <hex>",
+			Description: "convert hexadecimal to decimal",
+			Run:         numberCommand(16, 10),
+		},
+		"bin2dec": {
+			Usage:       "bin2dec <bin>",
+			Description: "convert binary to decimal",
+			Run:         numberCommand(2, 10),
+		},
+		"oct2dec": {
+			Usage:       "oct2dec <octal>",
+			Description: "convert octal to decimal",
+			Run:         numberCommand(8, 10),
+		},
+		"radix": {
+			Usage:       "radix <from> <to> <number>",
+			Description: "convert a number between any two bases from 2 to 36",
+			Run:         runRadixCommand,
+		},
+		"twos": {
+			Usage:       "twos <width> <number>",
+			Description: "show the two's complement pattern and signed and unsigned values at a bit width",
+			Run:         runTwosCommand,
+		},
+	}
+}
+
+func (bench *BitwiseWorkbench) Title() string {
+	return "Perform bitwise operations"
+}
+
+func (bench *BitwiseWorkbench) Category() string {
+	return categoryNumberBases
+}
+
+func (bench *BitwiseWorkbench) Height() unit.Dp {
+	return 200
+}
+
+func (bench *BitwiseWorkbench) State() map[string]string {
+	return inputState(bench)
+}
+
+func (bench *BitwiseWorkbench) SetState(state map[string]string) {
+	setInputState(bench, state)
+}
+
+func (bench *BitwiseWorkbench) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"and": {
+			Usage:       "and <bin> <bin>",
+			Description: "perform AND operation on two binary numbers",
+			Run:         stringCommand(2, func(args []string) (string, error) { return calc.ANDBinaryNumbers(args[0], args[1]) }),
+		},
+		"bitwise": {
+			Usage:       "bitwise <width> <op> <a> [b]",
+			Description: "apply AND, OR, XOR, NAND, NOR, NOT, SHL, SHR, SAL, SAR, ROL or ROR at a bit width",
+			Run:         runBitwiseCommand,
+		},
+	}
+}
+
+func (calculator *ChecksumCalculator) Title() string {
+	return "Compute or verify the Internet checksum (RFC 1071) of hex bytes"
+}
+
+func (calculator *ChecksumCalculator) Category() string {
+	return categoryErrorDetection
+}
+
+func (calculator *ChecksumCalculator) Height() unit.Dp {
+	return 200
+}
+
+func (calculator *ChecksumCalculator) State() map[string]string {
+	return inputState(calculator)
+}
+
+func (calculator *ChecksumCalculator) SetState(state map[string]string) {
+	setInputState(calculator, state)
+}
+
+func (calculator *ChecksumCalculator) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"checksum": {
+			Usage:       "checksum <hex bytes>",
+			Description: "compute the Internet checksum (RFC 1071)",
+			Run:         runChecksumCommand,
+		},
+		"verify-checksum": {
+			Usage:       "verify-checksum <hex bytes>",
+			Description: "verify data that contains its Internet checksum",
+			Run:         runVerifyChecksumCommand,
+		},
+	}
+}
+
+func (calculator *CRCCalculator) Title() string {
+	return "Compute the CRC of hex bytes"
+}
+
+func (calculator *CRCCalculator) Category() string {
+	return categoryErrorDetection
+}
+
+func (calculator *CRCCalculator) Height() unit.Dp {
+	return 260
+}
+
+func (calculator *CRCCalculator) State() map[string]string {
+	return inputState(calculator)
+}
+
+func (calculator *CRCCalculator) SetState(state map[string]string) {
+	setInputState(calculator, state)
+}
+
+func (calculator *CRCCalculator) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"crc": {
+			Usage:       "crc <preset>|--poly <poly> [...] <hex bytes>",
+			Description: "compute a CRC using a preset (" + crcPresetNames() + ") or --poly with --width, --init, --xorout, --refin and --refout",
+			Run:         runCRCCommand,
+		},
+	}
+}
+
+func (inspector *PasteInspector) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"identify": {
+			Usage:       "identify <anything>",
+			Description: "recognize an address, prefix, mask, MAC address, number or hex bytes and show all results",
+			Run:         runIdentifyCommand,
+		},
+	}
+}
+
+func (view *HistoryView) Commands() map[string]cliCommand {
+	return map[string]cliCommand{
+		"history": {
+			Usage:       "history [search words]",
+			Description: "list the calculations recorded by the GUI, newest first",
+			Run:         runHistoryCommand,
+		},
+	}
+}
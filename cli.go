@@ -0,0 +1,823 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/KhangBBBB/netcalc/calc"
+)
+
+const (
+	exitOK           = 0
+	exitInvalidInput = 1
+	exitUsage        = 2
+)
+
+var (
+	errUsage         = errors.New("wrong number of arguments")
+	errNotRecognized = errors.New("input is not recognized")
+)
+
+type cliCommand struct {
+	Usage       string
+	Description string
+	Run         func(args []string) (cliOutput, error)
+}
+
+// cliOutput holds both renderings of a command result: Text is printed as
+// is, and JSON is marshalled when --json is given. Failed results, such as
+// data whose checksum does not verify, are printed all the same but exit
+// with exitInvalidInput, so that scripts can check them.
+type cliOutput struct {
+	Text   string
+	JSON   interface{}
+	Failed bool
+}
+
+// cliCommands holds the commands registered by RegisterCalculator and
+// registerCommands, keyed by name.
+var cliCommands = map[string]cliCommand{}
+
+// RunCLI runs a single command without opening a window and returns the
+// process exit code.
+func RunCLI(args []string, stdout io.Writer, stderr io.Writer) int {
+	jsonOutput := false
+
+	// Options are only recognized before the command, so that its arguments
+	// may be "help" or start with a dash.
+	for len(args) > 0 && (strings.HasPrefix(args[0], "-") || args[0] == "help") {
+		switch args[0] {
+		case "--json", "-json":
+			jsonOutput = true
+		case "--help", "-help", "-h", "help":
+			printCLIUsage(stdout)
+			return exitOK
+		default:
+			fmt.Fprintf(stderr, "netcalc: unknown option %q\n\n", args[0])
+			printCLIUsage(stderr)
+			return exitUsage
+		}
+
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		printCLIUsage(stderr)
+		return exitUsage
+	}
+
+	command, ok := cliCommands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "netcalc: unknown command %q\n\n", args[0])
+		printCLIUsage(stderr)
+		return exitUsage
+	}
+
+	output, err := command.Run(args[1:])
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(stderr, "usage: netcalc %s\n", command.Usage)
+		return exitUsage
+	} else if err != nil {
+		fmt.Fprintf(stderr, "netcalc: %v\n", err)
+		return exitInvalidInput
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+
+		if err := encoder.Encode(output.JSON); err != nil {
+			fmt.Fprintf(stderr, "netcalc: %v\n", err)
+			return exitInvalidInput
+		}
+	} else {
+		fmt.Fprintln(stdout, output.Text)
+	}
+
+	if output.Failed {
+		return exitInvalidInput
+	}
+
+	return exitOK
+}
+
+func printCLIUsage(w io.Writer) {
+	names := make([]string, 0, len(cliCommands))
+	for name := range cliCommands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage: netcalc [--json] <command> [arguments]")
+	fmt.Fprintln(w, "\nRun without arguments to open the GUI. Commands:")
+
+	for _, name := range names {
+		command := cliCommands[name]
+		fmt.Fprintf(w, "  %-46s %s\n", command.Usage, command.Description)
+	}
+}
+
+// stringCommand wraps a compute function with a single string result. A
+// negative argc accepts one or more arguments, so that grouped hex and
+// binary numbers can be passed without quoting.
+func stringCommand(argc int, compute func(args []string) (string, error)) func(args []string) (cliOutput, error) {
+	return func(args []string) (cliOutput, error) {
+		if (argc >= 0 && len(args) != argc) || (argc < 0 && len(args) == 0) {
+			return cliOutput{}, errUsage
+		}
+
+		result, err := compute(args)
+		if err != nil {
+			return cliOutput{}, err
+		}
+
+		return cliOutput{Text: result, JSON: map[string]string{"result": result}}, nil
+	}
+}
+
+// addrCommand wraps a parser of a grouped hex or binary address. The groups
+// may be passed as separate arguments.
+func addrCommand(parse func(string) (netip.Addr, error)) func(args []string) (cliOutput, error) {
+	return stringCommand(-1, func(args []string) (string, error) {
+		addr, err := parse(strings.Join(args, ""))
+		if err != nil {
+			return "", err
+		}
+
+		return addr.String(), nil
+	})
+}
+
+// numberCommand converts an arbitrarily large integer between two radixes.
+func numberCommand(from int, to int) func(args []string) (cliOutput, error) {
+	return stringCommand(-1, func(args []string) (string, error) {
+		value, err := calc.ParseBigNumber(strings.Join(args, ""), from)
+		if err != nil {
+			return "", err
+		}
+
+		return calc.FormatBigNumber(value, to), nil
+	})
+}
+
+func runRadixCommand(args []string) (cliOutput, error) {
+	if len(args) < 3 {
+		return cliOutput{}, errUsage
+	}
+
+	from, errFrom := strconv.Atoi(args[0])
+	to, errTo := strconv.Atoi(args[1])
+
+	if errFrom != nil || errTo != nil || to < calc.MinRadix || to > calc.MaxRadix {
+		return cliOutput{}, fmt.Errorf("radix: %w", calc.ErrInvalidRadix)
+	}
+
+	return numberCommand(from, to)(args[2:])
+}
+
+func runTwosCommand(args []string) (cliOutput, error) {
+	if len(args) != 2 {
+		return cliOutput{}, errUsage
+	}
+
+	width, err := strconv.Atoi(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("twos: %w", calc.ErrInvalidWidth)
+	}
+
+	pattern, err := calc.ParseBitwiseOperand(args[1], width)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	signed, _ := calc.DecodeTwosComplement(pattern, width, true)
+	unsigned, _ := calc.DecodeTwosComplement(pattern, width, false)
+
+	result := map[string]string{
+		"bin":      calc.FormatBitwiseBin(pattern, width),
+		"hex":      calc.FormatBitwiseHex(pattern, width),
+		"signed":   signed.String(),
+		"unsigned": unsigned.String(),
+	}
+
+	return cliOutput{
+		Text: fmt.Sprintf("Bin:      %s\nHex:      %s\nSigned:   %s\nUnsigned: %s",
+			result["bin"], result["hex"], result["signed"], result["unsigned"]),
+		JSON: result,
+	}, nil
+}
+
+func runNetAddrCommand(args []string) (string, error) {
+	if len(args) > 2 {
+		return "", errUsage
+	}
+
+	prefix, err := parseHostPrefix(args[0], strings.Join(args[1:], " "))
+	if err != nil {
+		return "", err
+	}
+
+	return prefix.Masked().String(), nil
+}
+
+func runBitmaskCommand(args []string) (cliOutput, error) {
+	if len(args) != 2 {
+		return cliOutput{}, errUsage
+	}
+
+	ipAddr, err := netip.ParseAddr(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("bitmask: %w", calc.ErrInvalidAddress)
+	}
+
+	bitmask, err := netip.ParseAddr(args[1])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("bitmask: %w", calc.ErrInvalidMask)
+	}
+
+	result, err := calc.ApplyBitmask(ipAddr, bitmask)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(formatBitmaskSteps(ipAddr, bitmask, result), "\n"),
+		JSON: map[string]string{"result": result.String()},
+	}, nil
+}
+
+func runSubnetInfoCommand(args []string) (cliOutput, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return cliOutput{}, errUsage
+	}
+
+	prefix, err := parseHostPrefix(args[0], strings.Join(args[1:], " "))
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	details, err := describeHostPrefix(prefix)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(formatSubnetDetails(details), "\n"),
+		JSON: details,
+	}, nil
+}
+
+func runClassifyCommand(args []string) (cliOutput, error) {
+	if len(args) != 1 {
+		return cliOutput{}, errUsage
+	}
+
+	ipAddr, err := netip.ParseAddr(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("classify: %w", calc.ErrInvalidAddress)
+	}
+
+	checks := []struct {
+		name  string
+		check func(netip.Addr) bool
+	}{
+		{"private", calc.IsPrivateIP},
+		{"loopback", calc.IsLoopbackIP},
+		{"link-local-unicast", calc.IsLinkLocalUnicastIP},
+		{"multicast", calc.IsMulticastIP},
+	}
+
+	var text strings.Builder
+	result := make(map[string]bool, len(checks))
+
+	for _, c := range checks {
+		value := c.check(ipAddr)
+		result[c.name] = value
+		fmt.Fprintf(&text, "%s: %t\n", c.name, value)
+	}
+
+	return cliOutput{Text: strings.TrimSuffix(text.String(), "\n"), JSON: result}, nil
+}
+
+func runSpecialCommand(args []string) (cliOutput, error) {
+	if len(args) != 1 {
+		return cliOutput{}, errUsage
+	}
+
+	ipAddr, err := netip.ParseAddr(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("special: %w", calc.ErrInvalidAddress)
+	}
+
+	blocks, err := calc.LookupSpecialPurpose(ipAddr)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(formatSpecialPurposeBlocks(ipAddr, blocks), "\n"),
+		JSON: map[string]interface{}{"blocks": blocks},
+	}, nil
+}
+
+func runBogonsCommand(args []string) (cliOutput, error) {
+	if len(args) < 1 {
+		return cliOutput{}, errUsage
+	}
+
+	categories := args[1:]
+	if len(categories) == 0 {
+		categories = calc.DefaultBogonCategories()
+	}
+
+	prefixes, err := calc.BogonPrefixes(categories)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	filter, err := calc.FormatBogonFilter(prefixes, args[0], "BOGONS")
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.TrimSuffix(filter, "\n"),
+		JSON: map[string]interface{}{"prefixes": prefixes, "filter": filter},
+	}, nil
+}
+
+func runBitwiseCommand(args []string) (cliOutput, error) {
+	if len(args) < 3 {
+		return cliOutput{}, errUsage
+	}
+
+	width, err := strconv.Atoi(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("bitwise: %w", calc.ErrInvalidWidth)
+	}
+
+	op, err := calc.ParseBitwiseOperator(args[1])
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	if (op.Unary() && len(args) != 3) || (!op.Unary() && len(args) != 4) {
+		return cliOutput{}, errUsage
+	}
+
+	a, err := calc.ParseBitwiseOperand(args[2], width)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	b := new(big.Int)
+
+	if op.Shift() {
+		b, err = calc.ParseBitwiseOperand(args[3], 32)
+	} else if !op.Unary() {
+		b, err = calc.ParseBitwiseOperand(args[3], width)
+	}
+
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	result, err := calc.ApplyBitwise(op, a, b, width)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(formatBitwiseTable(op, a, b, result, width), "\n"),
+		JSON: map[string]string{
+			"bin": calc.FormatBitwiseBin(result, width),
+			"hex": calc.FormatBitwiseHex(result, width),
+			"dec": result.String(),
+		},
+	}, nil
+}
+
+func runRangeToCIDRCommand(args []string) (cliOutput, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return cliOutput{}, errUsage
+	}
+
+	start, end, err := parseAddrRangeArgs(args)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	prefixes, steps, err := calc.RangeToCIDRs(start, end)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(append(formatNumberedSteps(steps), joinPrefixes(prefixes, "\n")), "\n"),
+		JSON: map[string]interface{}{"steps": steps, "prefixes": prefixes},
+	}, nil
+}
+
+func runCIDRToRangeCommand(args []string) (cliOutput, error) {
+	if len(args) != 1 {
+		return cliOutput{}, errUsage
+	}
+
+	prefix, err := netip.ParsePrefix(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("cidr2range: %w", calc.ErrInvalidPrefixLength)
+	}
+
+	first, last, steps, err := calc.PrefixRange(prefix)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(append(formatNumberedSteps(steps), first.String()+"-"+last.String()), "\n"),
+		JSON: map[string]interface{}{"steps": steps, "first": first, "last": last},
+	}, nil
+}
+
+func runAggregateCommand(args []string) (cliOutput, error) {
+	if len(args) == 0 {
+		return cliOutput{}, errUsage
+	}
+
+	prefixes, err := parsePrefixList(strings.Join(args, " "))
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("aggregate: %w", err)
+	}
+
+	aggregated, steps, err := calc.AggregatePrefixes(prefixes)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(append(formatNumberedSteps(steps), joinPrefixes(aggregated, "\n")), "\n"),
+		JSON: map[string]interface{}{"steps": steps, "prefixes": aggregated},
+	}, nil
+}
+
+func runSupernetCommand(args []string) (cliOutput, error) {
+	if len(args) == 0 {
+		return cliOutput{}, errUsage
+	}
+
+	prefixes, err := parsePrefixList(strings.Join(args, " "))
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("supernet: %w", err)
+	}
+
+	_, aggregateSteps, err := calc.AggregatePrefixes(prefixes)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	supernet, extra, steps, err := calc.Supernet(prefixes)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(formatSupernetSteps(aggregateSteps, steps, supernet, extra), "\n"),
+		JSON: map[string]interface{}{"steps": append(aggregateSteps, steps...), "supernet": supernet, "extra": extra},
+	}, nil
+}
+
+func runMaskToWildcardCommand(args []string) (cliOutput, error) {
+	if len(args) != 1 {
+		return cliOutput{}, errUsage
+	}
+
+	netMask, err := netip.ParseAddr(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("mask2wildcard: %w", calc.ErrInvalidMask)
+	}
+
+	wildcard, err := calc.NetworkMaskToWildcard(netMask)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{Text: wildcard.String(), JSON: wildcard}, nil
+}
+
+func runWildcardToMaskCommand(args []string) (cliOutput, error) {
+	if len(args) != 1 {
+		return cliOutput{}, errUsage
+	}
+
+	wildcard, err := netip.ParseAddr(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("wildcard2mask: %w", calc.ErrInvalidMask)
+	}
+
+	netMask, err := calc.WildcardToNetworkMask(wildcard)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{Text: netMask.String(), JSON: netMask}, nil
+}
+
+func runACLCommand(args []string) (cliOutput, error) {
+	if len(args) < 3 {
+		return cliOutput{}, errUsage
+	}
+
+	prefixes, err := parseACLTargets(strings.Join(args[2:], " "))
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("acl: %w", err)
+	}
+
+	entries, steps, err := calc.WildcardEntries(prefixes)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	acl, err := calc.FormatACL(entries, args[0], args[1])
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(append(formatNumberedSteps(steps), strings.TrimSuffix(acl, "\n")), "\n"),
+		JSON: map[string]interface{}{"steps": steps, "entries": entries, "acl": acl},
+	}, nil
+}
+
+func runIdentifyCommand(args []string) (cliOutput, error) {
+	if len(args) == 0 {
+		return cliOutput{}, errUsage
+	}
+
+	interpretations := interpretPaste(strings.Join(args, " "))
+	if len(interpretations) == 0 {
+		return cliOutput{}, fmt.Errorf("identify: %q: %w", strings.Join(args, " "), errNotRecognized)
+	}
+
+	return cliOutput{
+		Text: strings.Join(formatInterpretations(interpretations), "\n"),
+		JSON: interpretations,
+	}, nil
+}
+
+func runHistoryCommand(args []string) (cliOutput, error) {
+	historyPath, err := defaultHistoryPath()
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	h, err := loadHistory(historyPath)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	entries := h.Search(strings.Join(args, " "))
+	lines := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		lines = append(lines, fmt.Sprintf("%s  %s  %s", entry.Time.Local().Format("2006-01-02 15:04"), entry.Calculator, entry.Summary()))
+	}
+
+	if entries == nil {
+		entries = []historyEntry{}
+	}
+
+	return cliOutput{Text: strings.Join(lines, "\n"), JSON: entries}, nil
+}
+
+func runSubnetCommand(args []string) (cliOutput, error) {
+	if len(args) != 3 {
+		return cliOutput{}, errUsage
+	}
+
+	network, err := netip.ParsePrefix(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("subnet: %w", calc.ErrInvalidAddress)
+	}
+
+	count, err := strconv.Atoi(args[2])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("subnet: %w", calc.ErrInvalidCount)
+	}
+
+	var subnets []calc.Subnet
+	var steps []string
+
+	switch args[1] {
+	case "subnets":
+		subnets, steps, err = calc.SplitNetworkBySubnetCount(network, count)
+	case "hosts":
+		subnets, steps, err = calc.SplitNetworkByHostCount(network, count)
+	default:
+		return cliOutput{}, errUsage
+	}
+
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(formatSubnettingSteps(subnets, steps), "\n"),
+		JSON: map[string]interface{}{"steps": steps, "subnets": subnets},
+	}, nil
+}
+
+func runVLSMCommand(args []string) (cliOutput, error) {
+	if len(args) < 2 {
+		return cliOutput{}, errUsage
+	}
+
+	network, err := netip.ParsePrefix(args[0])
+	if err != nil {
+		return cliOutput{}, fmt.Errorf("vlsm: %w", calc.ErrInvalidAddress)
+	}
+
+	requirements, err := calc.ParseVLSMRequirements(strings.Join(args[1:], " "))
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	plan, steps, err := calc.PlanVLSM(network, requirements)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	return cliOutput{
+		Text: strings.Join(formatVLSMSteps(plan, steps, nil), "\n"),
+		JSON: map[string]interface{}{"steps": steps, "plan": plan},
+	}, nil
+}
+
+func runChecksumCommand(args []string) (cliOutput, error) {
+	if len(args) == 0 {
+		return cliOutput{}, errUsage
+	}
+
+	data, err := calc.ParseHexBytes(strings.Join(args, " "))
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	checksum, steps := calc.InternetChecksum(data)
+	checksumValue := fmt.Sprintf("%04X", checksum)
+
+	return cliOutput{
+		Text: strings.Join(append(steps, checksumValue), "\n"),
+		JSON: map[string]interface{}{"steps": steps, "checksum": checksumValue},
+	}, nil
+}
+
+func runVerifyChecksumCommand(args []string) (cliOutput, error) {
+	if len(args) == 0 {
+		return cliOutput{}, errUsage
+	}
+
+	data, err := calc.ParseHexBytes(strings.Join(args, " "))
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	valid, steps := calc.VerifyInternetChecksum(data)
+
+	return cliOutput{
+		Text:   strings.Join(append(steps, strconv.FormatBool(valid)), "\n"),
+		JSON:   map[string]interface{}{"steps": steps, "valid": valid},
+		Failed: !valid,
+	}, nil
+}
+
+func runCRCCommand(args []string) (cliOutput, error) {
+	if len(args) == 0 {
+		return cliOutput{}, errUsage
+	}
+
+	var params calc.CRCParameters
+	var err error
+
+	if strings.HasPrefix(args[0], "--") {
+		params, args, err = parseCRCOptions(args)
+	} else {
+		params, err = calc.FindCRCPreset(args[0])
+		args = args[1:]
+	}
+
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	if len(args) == 0 {
+		return cliOutput{}, errUsage
+	}
+
+	data, err := calc.ParseHexBytes(strings.Join(args, " "))
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	crc, err := calc.ComputeCRC(params, data)
+	if err != nil {
+		return cliOutput{}, err
+	}
+
+	result := calc.FormatCRC(params, crc)
+
+	return cliOutput{Text: result, JSON: map[string]string{"algorithm": params.Name, "crc": result}}, nil
+}
+
+// parseCRCOptions reads the parameters of a custom CRC from the options
+// before the data, and returns the remaining arguments. --poly is required,
+// and --width overrides the width implied by the polynomial, so that "0x7"
+// can be used for CRC-8.
+func parseCRCOptions(args []string) (calc.CRCParameters, []string, error) {
+	params := calc.CRCParameters{Name: crcCustom}
+	polynomial := ""
+	width := 0
+
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		option := args[0]
+		args = args[1:]
+
+		switch option {
+		case "--refin":
+			params.ReflectIn = true
+			continue
+		case "--refout":
+			params.ReflectOut = true
+			continue
+		case "--poly", "--width", "--init", "--xorout":
+		default:
+			return calc.CRCParameters{}, nil, errUsage
+		}
+
+		if len(args) == 0 {
+			return calc.CRCParameters{}, nil, errUsage
+		}
+
+		value := args[0]
+		args = args[1:]
+
+		var err error
+
+		switch option {
+		case "--poly":
+			polynomial = value
+		case "--width":
+			width, err = strconv.Atoi(value)
+			if err != nil || width < 1 || width > 64 {
+				err = calc.ErrInvalidCRCWidth
+			}
+		case "--init":
+			params.Init, err = parseOptionalHex(value)
+		case "--xorout":
+			params.XorOut, err = parseOptionalHex(value)
+		}
+
+		if err != nil {
+			return calc.CRCParameters{}, nil, fmt.Errorf("crc: %s %q: %w", option, value, err)
+		}
+	}
+
+	if polynomial == "" {
+		return calc.CRCParameters{}, nil, errUsage
+	}
+
+	polynomialWidth, value, err := calc.ParseCRCPolynomial(polynomial)
+	if err != nil {
+		return calc.CRCParameters{}, nil, err
+	}
+
+	params.Width = polynomialWidth
+	params.Polynomial = value
+
+	if width != 0 {
+		if value>>width != 0 {
+			return calc.CRCParameters{}, nil, fmt.Errorf("crc: polynomial %s does not fit in %d bits: %w", polynomial, width, calc.ErrInvalidPolynomial)
+		}
+
+		params.Width = width
+	}
+
+	return params, args, nil
+}
+
+func crcPresetNames() string {
+	names := make([]string, 0, len(calc.CRCPresets))
+	for _, preset := range calc.CRCPresets {
+		names = append(names, preset.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
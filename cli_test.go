@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func runCLIForTest(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := RunCLI(args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunCLIOptions(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{[]string{"ip2hex", "10.0.0.1"}, exitOK, "0A000001\n"},
+		{[]string{"--json", "ip2hex", "10.0.0.1"}, exitOK, "{\n  \"result\": \"0A000001\"\n}\n"},
+		{[]string{"help"}, exitOK, "usage: netcalc"},
+		{[]string{"--bogus", "ip2hex", "10.0.0.1"}, exitUsage, ""},
+		// Options after the command are its arguments.
+		{[]string{"ip2hex", "help"}, exitInvalidInput, ""},
+		{[]string{"ip2hex", "--json"}, exitInvalidInput, ""},
+	}
+
+	for _, test := range tests {
+		code, stdout, _ := runCLIForTest(test.args...)
+		if code != test.wantCode || !strings.HasPrefix(stdout, test.wantOut) {
+			t.Errorf("RunCLI(%q) = %d, %q, want %d, %q", test.args, code, stdout, test.wantCode, test.wantOut)
+		}
+	}
+}
+
+func TestVerifyChecksumExitCode(t *testing.T) {
+	if code, stdout, _ := runCLIForTest("verify-checksum", "45 00 00 1c 00 00 00 00 40 11 f7 7d c0 a8 01 01 c0 a8 01 02"); code != exitOK || !strings.HasSuffix(stdout, "true\n") {
+		t.Errorf("verify-checksum of valid data = %d, %q, want %d and true", code, stdout, exitOK)
+	}
+
+	if code, stdout, _ := runCLIForTest("verify-checksum", "45 00 00 1c 00 00 00 00 40 11 f7 7e c0 a8 01 01 c0 a8 01 02"); code != exitInvalidInput || !strings.HasSuffix(stdout, "false\n") {
+		t.Errorf("verify-checksum of corrupted data = %d, %q, want %d and false", code, stdout, exitInvalidInput)
+	}
+}
+
+func TestCRCCommand(t *testing.T) {
+	data := "31 32 33 34 35 36 37 38 39"
+
+	tests := []struct {
+		args     string
+		wantCode int
+		wantOut  string
+	}{
+		{"crc CRC-8 " + data, exitOK, "F4\n"},
+		{"crc --poly 0x07 " + data, exitOK, "F4\n"},
+		{"crc --poly 0x7 --width 8 " + data, exitOK, "F4\n"},
+		{"crc --poly 100000111 " + data, exitOK, "F4\n"},
+		{"crc --poly 0x1021 --init 0xFFFF " + data, exitOK, "29B1\n"},
+		{"crc --poly 0x04C11DB7 --init 0xFFFFFFFF --refin --refout --xorout 0xFFFFFFFF " + data, exitOK, "CBF43926\n"},
+		{"crc --poly 0x107 --width 8 " + data, exitInvalidInput, ""},
+		{"crc --poly 0x07 --width 65 " + data, exitInvalidInput, ""},
+		{"crc --poly 0x07 --init xyz " + data, exitInvalidInput, ""},
+		{"crc --init 0xFF " + data, exitUsage, ""},
+		{"crc --poly 0x07 --bogus " + data, exitUsage, ""},
+		{"crc --poly 0x07", exitUsage, ""},
+		{"crc --poly", exitUsage, ""},
+	}
+
+	for _, test := range tests {
+		code, stdout, _ := runCLIForTest(strings.Fields(test.args)...)
+		if code != test.wantCode || stdout != test.wantOut {
+			t.Errorf("netcalc %s = %d, %q, want %d, %q", test.args, code, stdout, test.wantCode, test.wantOut)
+		}
+	}
+}
+
+func TestACLCommandFewestEntries(t *testing.T) {
+	code, stdout, _ := runCLIForTest("acl", "10", "permit", "10.1.0.1-10.1.0.6")
+	if code != exitOK || strings.Count(stdout, "access-list 10 permit") != 3 {
+		t.Errorf("netcalc acl 10 permit 10.1.0.1-10.1.0.6 = %d, %q, want 3 entries", code, stdout)
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+
+	"github.com/KhangBBBB/netcalc/calc"
+)
+
+// pasteInterpretation is one way of reading pasted input, with the results
+// of every calculation that applies to it.
+type pasteInterpretation struct {
+	Kind  string
+	Lines []string
+}
+
+// interpretPaste tries every kind of input netcalc understands on text and
+// returns each interpretation that parses, most specific first. Ambiguous
+// input such as "10" is returned as a decimal, binary and hexadecimal number.
+func interpretPaste(text string) []pasteInterpretation {
+	trimmedText := strings.TrimSpace(text)
+	if trimmedText == "" {
+		return nil
+	}
+
+	var interpretations []pasteInterpretation
+
+	for _, interpret := range []func(string) []pasteInterpretation{
+		interpretPrefix,
+		interpretAddrRange,
+		interpretAddr,
+		interpretMAC,
+		interpretDec,
+		interpretBin,
+		interpretHex,
+	} {
+		interpretations = append(interpretations, interpret(trimmedText)...)
+	}
+
+	return interpretations
+}
+
+func interpretPrefix(text string) []pasteInterpretation {
+	prefix, err := calc.ParseHostPrefix(text)
+	if err != nil {
+		return nil
+	}
+
+	if prefix.Addr().Is4() {
+		netMask, _ := calc.CIDRSlashValueToNetworkMask(prefix.Bits())
+
+		details, err := calc.DescribeSubnet(prefix.Addr(), netMask)
+		if err != nil {
+			return nil
+		}
+
+		return []pasteInterpretation{{"IPv4 address with prefix", formatSubnetDetails(details)}}
+	}
+
+	first, last, _, _ := calc.PrefixRange(prefix)
+
+	return []pasteInterpretation{{"IPv6 address with prefix", []string{
+		fmt.Sprintf("Network:          %s", prefix.Masked()),
+		fmt.Sprintf("First address:    %s", first),
+		fmt.Sprintf("Last address:     %s", last),
+		fmt.Sprintf("Addresses:        2^%d", prefix.Addr().BitLen()-prefix.Bits()),
+	}}}
+}
+
+func interpretAddrRange(text string) []pasteInterpretation {
+	start, end, err := calc.ParseAddrRange(text)
+	if err != nil {
+		return nil
+	}
+
+	prefixes, _, err := calc.RangeToCIDRs(start, end)
+	if err != nil {
+		return nil
+	}
+
+	return []pasteInterpretation{{"Address range", []string{"Prefixes: " + joinPrefixes(prefixes, ", ")}}}
+}
+
+func interpretAddr(text string) []pasteInterpretation {
+	ipAddress, err := netip.ParseAddr(text)
+	if err != nil {
+		return nil
+	}
+
+	blocks, _ := calc.LookupSpecialPurpose(ipAddress)
+
+	var interpretations []pasteInterpretation
+
+	if ipAddress.Is4() {
+		hexValue, _ := calc.IPv4ToHexFormat(ipAddress)
+		class, _ := calc.IPv4AddressClass(ipAddress)
+
+		lines := []string{
+			fmt.Sprintf("Hex:    %s", hexValue),
+			fmt.Sprintf("Bin:    %s", formatAddrBin(ipAddress)),
+			fmt.Sprintf("Class:  %s", class),
+		}
+
+		interpretations = append(interpretations, pasteInterpretation{"IPv4 address", append(lines, formatSpecialPurposeBlocks(ipAddress, blocks)...)})
+
+		if ones, err := calc.NetworkMaskToCIDRSlashValue(ipAddress); err == nil {
+			wildcard, _ := calc.NetworkMaskToWildcard(ipAddress)
+			interpretations = append(interpretations, pasteInterpretation{"IPv4 network mask", []string{
+				fmt.Sprintf("CIDR slash value:  %s", calc.FormatCIDRSlashValue(ones)),
+				fmt.Sprintf("Wildcard mask:     %s", wildcard),
+			}})
+		} else if netMask, err := calc.WildcardToNetworkMask(ipAddress); err == nil {
+			ones, _ := calc.NetworkMaskToCIDRSlashValue(netMask)
+			interpretations = append(interpretations, pasteInterpretation{"Wildcard mask", []string{
+				fmt.Sprintf("Network mask:      %s", netMask),
+				fmt.Sprintf("CIDR slash value:  %s", calc.FormatCIDRSlashValue(ones)),
+			}})
+		}
+
+		return interpretations
+	}
+
+	hexValue, _ := calc.IPv6ToHexFormat(ipAddress)
+
+	lines := []string{
+		fmt.Sprintf("Hex:  %s", hexValue),
+		fmt.Sprintf("Bin:  %s", formatAddrBin(ipAddress)),
+	}
+
+	interpretations = append(interpretations, pasteInterpretation{"IPv6 address", append(lines, formatSpecialPurposeBlocks(ipAddress, blocks)...)})
+
+	if ones, err := calc.IPv6MaskToCIDRSlashValue(ipAddress); err == nil && ones > 0 {
+		interpretations = append(interpretations, pasteInterpretation{"IPv6 mask", []string{
+			fmt.Sprintf("CIDR slash value:  %s", calc.FormatCIDRSlashValue(ones)),
+		}})
+	}
+
+	return interpretations
+}
+
+func interpretMAC(text string) []pasteInterpretation {
+	mac, err := calc.ParseMAC(text)
+	if err != nil {
+		return nil
+	}
+
+	scope := "globally unique (OUI " + mac.OUI() + ")"
+	if mac.IsLocallyAdministered() {
+		scope = "locally administered"
+	}
+
+	cast := "unicast"
+	if mac.IsMulticast() {
+		cast = "multicast"
+	}
+
+	return []pasteInterpretation{{"MAC address", []string{
+		fmt.Sprintf("Formats:     %s  %s  %s", mac, mac.Hyphenated(), mac.CiscoFormat()),
+		fmt.Sprintf("Type:        %s, %s", cast, scope),
+		fmt.Sprintf("Link-local:  %s (modified EUI-64)", mac.LinkLocalAddress()),
+	}}}
+}
+
+func interpretDec(text string) []pasteInterpretation {
+	if strings.ContainsAny(text, " \t") {
+		return nil
+	}
+
+	value, err := calc.ParseBigNumber(text, 10)
+	if err != nil {
+		return nil
+	}
+
+	interpretations := []pasteInterpretation{{"Decimal number", formatNumberBases(value)}}
+
+	// Checked on the big.Int, since int is only 32 bits wide on some
+	// platforms.
+	if value.Sign() >= 0 && value.Cmp(big.NewInt(65535)) <= 0 {
+		if portRange, service, err := calc.DescribePort(int(value.Int64())); err == nil {
+			lines := []string{portRange}
+			if service != "" {
+				lines = append(lines, "Commonly used by "+service)
+			}
+
+			interpretations = append(interpretations, pasteInterpretation{"Port number", lines})
+		}
+	}
+
+	if value.Sign() >= 0 && value.BitLen() <= 32 {
+		ipAddress := netip.AddrFrom4(*(*[4]byte)(value.FillBytes(make([]byte, 4))))
+		interpretations = append(interpretations, pasteInterpretation{"IPv4 address as a 32-bit integer", []string{ipAddress.String()}})
+	}
+
+	return interpretations
+}
+
+func interpretBin(text string) []pasteInterpretation {
+	// Dots only separate the four octets of an address, so that addresses
+	// such as "10.0.0.1" are not read as binary numbers too.
+	if strings.Contains(text, ".") {
+		octets := strings.Split(text, ".")
+		if len(octets) != 4 {
+			return nil
+		}
+
+		for _, octet := range octets {
+			if len(strings.TrimSpace(octet)) != 8 {
+				return nil
+			}
+		}
+	}
+
+	digits := strings.NewReplacer(" ", "", ".", "").Replace(text)
+	if strings.Trim(digits, "01") != "" {
+		return nil
+	}
+
+	value, err := calc.ParseBigNumber(digits, 2)
+	if err != nil {
+		return nil
+	}
+
+	interpretations := []pasteInterpretation{{"Binary number", formatNumberBases(value)}}
+
+	if len(digits) == 32 {
+		ipAddress, err := calc.BinToIPv4Format(digits)
+		if err == nil {
+			interpretations = append(interpretations, pasteInterpretation{"IPv4 address in binary", []string{ipAddress.String()}})
+		}
+	}
+
+	return interpretations
+}
+
+func interpretHex(text string) []pasteInterpretation {
+	digits := strings.TrimPrefix(strings.TrimPrefix(text, "0x"), "0X")
+
+	var interpretations []pasteInterpretation
+
+	if value, err := calc.ParseBigNumber(digits, 16); err == nil && !strings.ContainsAny(digits, " \t") {
+		interpretations = append(interpretations, pasteInterpretation{"Hexadecimal number", formatNumberBases(value)})
+
+		if len(digits) == 8 {
+			if ipAddress, err := calc.HexToIPv4Format(digits); err == nil {
+				interpretations = append(interpretations, pasteInterpretation{"IPv4 address in hexadecimal", []string{ipAddress.String()}})
+			}
+		}
+	}
+
+	// Addresses like "fe80::1" would otherwise also parse as hex bytes.
+	if _, err := netip.ParseAddr(text); err == nil {
+		return interpretations
+	}
+
+	if data, err := calc.ParseHexBytes(digits); err == nil && len(data) > 1 {
+		checksum, _ := calc.InternetChecksum(data)
+		crc32, _ := calc.FindCRCPreset("CRC-32/Ethernet")
+		crc, _ := calc.ComputeCRC(crc32, data)
+
+		interpretations = append(interpretations, pasteInterpretation{"Hex bytes", []string{
+			fmt.Sprintf("Length:             %d bytes", len(data)),
+			fmt.Sprintf("Internet checksum:  0x%04X", checksum),
+			fmt.Sprintf("CRC-32/Ethernet:    %s", calc.FormatCRC(crc32, crc)),
+		}})
+	}
+
+	return interpretations
+}
+
+// formatInterpretations lists each interpretation under its kind.
+func formatInterpretations(interpretations []pasteInterpretation) []string {
+	if len(interpretations) == 0 {
+		return []string{"Not recognized as an address, prefix, range, mask, MAC address, number or hex bytes."}
+	}
+
+	var lines []string
+
+	for _, interpretation := range interpretations {
+		lines = append(lines, interpretation.Kind+":")
+
+		for _, line := range interpretation.Lines {
+			lines = append(lines, "    "+line)
+		}
+	}
+
+	return lines
+}
+
+func formatNumberBases(value *big.Int) []string {
+	return []string{
+		fmt.Sprintf("Dec:  %s", calc.FormatBigNumber(value, 10)),
+		fmt.Sprintf("Hex:  %s", calc.FormatBigNumber(value, 16)),
+		fmt.Sprintf("Oct:  %s", calc.FormatBigNumber(value, 8)),
+		fmt.Sprintf("Bin:  %s", calc.FormatBigNumber(value, 2)),
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInterpretPasteKinds(t *testing.T) {
+	tests := []struct {
+		text      string
+		wantKinds []string
+	}{
+		{"10.0.0.1", []string{"IPv4 address"}},
+		{"1.1.1.1", []string{"IPv4 address"}},
+		{"00001010.00000000.00000000.00000001", []string{"Binary number", "IPv4 address in binary"}},
+		{"1010.0000", nil},
+	}
+
+	for _, test := range tests {
+		var kinds []string
+
+		for _, interpretation := range interpretPaste(test.text) {
+			kinds = append(kinds, interpretation.Kind)
+		}
+
+		if fmt.Sprint(kinds) != fmt.Sprint(test.wantKinds) {
+			t.Errorf("interpretPaste(%q) kinds = %q, want %q", test.text, kinds, test.wantKinds)
+		}
+	}
+}
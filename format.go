@@ -0,0 +1,409 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+
+	"github.com/KhangBBBB/netcalc/calc"
+)
+
+func formatSubnettingSteps(subnets []calc.Subnet, steps []string) []string {
+	lines := make([]string, 0, len(steps)+len(subnets))
+
+	for i, step := range steps {
+		lines = append(lines, fmt.Sprintf("Step %d: %s", i+1, step))
+	}
+
+	for i, subnet := range subnets {
+		lines = append(lines, fmt.Sprintf("Subnet %d: %s  hosts %s - %s  broadcast %s  mask %s  (%d usable)",
+			i+1, subnet.Prefix, subnet.FirstUsableHost, subnet.LastUsableHost,
+			formatBroadcast(subnet.Prefix, subnet.Broadcast), subnet.NetworkMask, subnet.UsableHosts))
+	}
+
+	return lines
+}
+
+// formatBroadcast returns the broadcast address of an IPv4 network, or says
+// there is none for /31 and /32 networks, whose every address is a host.
+func formatBroadcast(prefix netip.Prefix, broadcast netip.Addr) string {
+	if prefix.Bits() >= 31 {
+		return "none (RFC 3021)"
+	}
+
+	return broadcast.String()
+}
+
+func formatSubnetDetails(details calc.SubnetDetails) []string {
+	hostRange := fmt.Sprintf("%s - %s", details.FirstUsableHost, details.LastUsableHost)
+
+	switch details.Prefix.Bits() {
+	case 31:
+		hostRange += " (point-to-point, RFC 3021)"
+	case 32:
+		hostRange += " (host route)"
+	}
+
+	return []string{
+		fmt.Sprintf("Network address:  %s", details.Prefix),
+		fmt.Sprintf("Broadcast:        %s", formatBroadcast(details.Prefix, details.Broadcast)),
+		fmt.Sprintf("Host range:       %s", hostRange),
+		fmt.Sprintf("Total addresses:  %d", details.TotalAddresses),
+		fmt.Sprintf("Usable hosts:     %d", details.UsableHosts),
+		fmt.Sprintf("Network mask:     %s", details.NetworkMask),
+		fmt.Sprintf("Wildcard mask:    %s", details.WildcardMask),
+		fmt.Sprintf("Address class:    %s", details.Class),
+	}
+}
+
+func formatVLSMSteps(plan calc.VLSMPlan, steps []string, err error) []string {
+	lines := make([]string, 0, len(steps)+len(plan.Allocations)+1)
+
+	for i, step := range steps {
+		lines = append(lines, fmt.Sprintf("Step %d: %s", i+1, step))
+	}
+
+	if err != nil {
+		return append(lines, err.Error())
+	}
+
+	for _, allocation := range plan.Allocations {
+		lines = append(lines, fmt.Sprintf("%s: %s  hosts %s - %s  broadcast %s  (%d needed, %d usable, %d wasted)",
+			allocation.Name, allocation.Prefix, allocation.FirstUsableHost, allocation.LastUsableHost,
+			formatBroadcast(allocation.Prefix, allocation.Broadcast), allocation.Hosts, allocation.UsableHosts, allocation.WastedHosts))
+	}
+
+	return lines
+}
+
+func parseNetworkMask(netMask string) (string, error) {
+	mask, err := netip.ParseAddr(strings.TrimSpace(netMask))
+	if err != nil {
+		return "", err
+	}
+
+	var ones int
+
+	if mask.Is4() {
+		ones, err = calc.NetworkMaskToCIDRSlashValue(mask)
+	} else {
+		ones, err = calc.IPv6MaskToCIDRSlashValue(mask)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return calc.FormatCIDRSlashValue(ones), nil
+}
+
+// parseCIDRSlashValue accepts a prefix length with or without the leading
+// "/" and returns the network mask of the given number of bits.
+func parseCIDRSlashValue(cidrSlashValue string, bits int) (string, error) {
+	ones, err := calc.ParsePrefixLength(cidrSlashValue, bits)
+	if err != nil {
+		return "", err
+	}
+
+	if bits == 32 {
+		mask, err := calc.CIDRSlashValueToNetworkMask(ones)
+		return mask.String(), err
+	}
+
+	mask, err := calc.CIDRSlashValueToIPv6Mask(ones)
+
+	return mask.String(), err
+}
+
+// parseHostPrefix joins the host and mask fields, so that the host may be
+// given in CIDR notation with the mask left empty, and parses them with
+// calc.ParseHostPrefix.
+func parseHostPrefix(hostIP string, netMask string) (netip.Prefix, error) {
+	if strings.TrimSpace(netMask) == "" {
+		return calc.ParseHostPrefix(hostIP)
+	}
+
+	return calc.ParseHostPrefix(strings.TrimSpace(hostIP) + " " + netMask)
+}
+
+// describeHostPrefix returns the subnet details of an IPv4 host prefix.
+func describeHostPrefix(prefix netip.Prefix) (calc.SubnetDetails, error) {
+	if !prefix.Addr().Is4() {
+		return calc.SubnetDetails{}, fmt.Errorf("describeHostPrefix: %w", calc.ErrNotIPv4)
+	}
+
+	netMask, err := calc.CIDRSlashValueToNetworkMask(prefix.Bits())
+	if err != nil {
+		return calc.SubnetDetails{}, err
+	}
+
+	return calc.DescribeSubnet(prefix.Addr(), netMask)
+}
+
+// hostPrefixInvalid decides which of a host and a mask field to flag for
+// err. With the mask left empty the host field holds both, and it is only
+// flagged once a prefix has been started.
+func hostPrefixInvalid(hostIP string, netMask string, err error) (bool, bool) {
+	if err == nil {
+		return false, false
+	}
+
+	if strings.TrimSpace(netMask) == "" {
+		return strings.ContainsAny(strings.TrimSpace(hostIP), "/ \t"), false
+	}
+
+	if errors.Is(err, calc.ErrInvalidAddress) || errors.Is(err, calc.ErrNotIPv4) || errors.Is(err, calc.ErrNotIPv6) {
+		return true, false
+	}
+
+	return false, true
+}
+
+// formatMaskProblem explains why netMask is not a contiguous mask by marking
+// the offending bit under its binary form. It returns nil for valid masks.
+func formatMaskProblem(netMask netip.Addr) []string {
+	bit, ok := calc.NonContiguousMaskBit(netMask)
+	if !ok {
+		return nil
+	}
+
+	binMask := formatAddrBin(netMask)
+
+	column, digits := 0, 0
+	for column < len(binMask) {
+		if binMask[column] == '0' || binMask[column] == '1' {
+			if digits == bit {
+				break
+			}
+
+			digits++
+		}
+
+		column++
+	}
+
+	return []string{
+		fmt.Sprintf("%s is not a contiguous network mask:", netMask),
+		binMask,
+		fmt.Sprintf("%s^ bit %d is 1 after a 0 bit", strings.Repeat(" ", column), bit),
+	}
+}
+
+// formatBitmaskSteps shows the AND of an address and an arbitrary bitmask
+// bit by bit.
+func formatBitmaskSteps(ipAddress netip.Addr, bitmask netip.Addr, result netip.Addr) []string {
+	return []string{
+		fmt.Sprintf("Address  %s  %s", formatAddrBin(ipAddress), ipAddress),
+		fmt.Sprintf("Bitmask  %s  %s", formatAddrBin(bitmask), bitmask),
+		fmt.Sprintf("AND      %s  %s", formatAddrBin(result), result),
+	}
+}
+
+// formatBitVisualization explains the colors of the bit visualizer and shows
+// the AND of the address and the mask that gives the network address,
+// followed by the details of the resulting subnet.
+func formatBitVisualization(ipAddress netip.Addr, networkPrefix int, prefix int) ([]string, error) {
+	netMask, err := calc.CIDRSlashValueToNetworkMask(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := calc.DescribeSubnet(ipAddress, netMask)
+	if err != nil {
+		return nil, err
+	}
+
+	legend := fmt.Sprintf("Blue: %d network bits, green: %d host bits.", prefix, 32-prefix)
+
+	if prefix > networkPrefix {
+		borrowed := prefix - networkPrefix
+		legend = fmt.Sprintf("Blue: %d network bits, orange: %d subnet bits borrowed from /%d (%d subnets), green: %d host bits.",
+			networkPrefix, borrowed, networkPrefix, uint64(1)<<borrowed, 32-prefix)
+	}
+
+	lines := []string{
+		legend,
+		fmt.Sprintf("Address  %s  %s", formatAddrBin(ipAddress), ipAddress),
+		fmt.Sprintf("Mask     %s  %s", formatAddrBin(netMask), netMask),
+		fmt.Sprintf("AND      %s  %s", formatAddrBin(details.Prefix.Addr()), details.Prefix.Addr()),
+	}
+
+	return append(lines, formatSubnetDetails(details)...), nil
+}
+
+func formatAddrBin(ipAddress netip.Addr) string {
+	if ipAddress.Is4() {
+		binValue, _ := calc.IPv4ToBinFormat(ipAddress)
+		return binValue
+	}
+
+	binValue, _ := calc.IPv6ToBinFormat(ipAddress)
+
+	return binValue
+}
+
+func formatSpecialPurposeBlocks(ipAddress netip.Addr, blocks []calc.SpecialPurposeBlock) []string {
+	lines := []string{fmt.Sprintf("Private: %t  Loopback: %t  Link-local unicast: %t  Multicast: %t",
+		calc.IsPrivateIP(ipAddress), calc.IsLoopbackIP(ipAddress), calc.IsLinkLocalUnicastIP(ipAddress), calc.IsMulticastIP(ipAddress))}
+
+	if len(blocks) == 0 {
+		return append(lines, "Not in any IANA special-purpose address block (RFC 6890).")
+	}
+
+	for _, block := range blocks {
+		allocation := "allocated " + block.AllocationDate
+		if block.TerminationDate != "" {
+			allocation += ", terminated " + block.TerminationDate
+		}
+
+		lines = append(lines,
+			fmt.Sprintf("%s: %s (%s, %s)", block.Prefix, block.Name, block.RFC, allocation),
+			fmt.Sprintf("    source %s, destination %s, forwardable %s, globally reachable %s, reserved-by-protocol %s",
+				block.Source, block.Destination, block.Forwardable, block.GloballyReachable, block.ReservedByProtocol),
+		)
+	}
+
+	return lines
+}
+
+func formatNumberedSteps(steps []string) []string {
+	lines := make([]string, 0, len(steps))
+
+	for i, step := range steps {
+		lines = append(lines, fmt.Sprintf("Step %d: %s", i+1, step))
+	}
+
+	return lines
+}
+
+func joinPrefixes(prefixes []netip.Prefix, separator string) string {
+	texts := make([]string, 0, len(prefixes))
+
+	for _, prefix := range prefixes {
+		texts = append(texts, prefix.String())
+	}
+
+	return strings.Join(texts, separator)
+}
+
+// parseAddrRangeArgs accepts a range either as one "start-end" argument or
+// as separate start and end arguments.
+func parseAddrRangeArgs(args []string) (netip.Addr, netip.Addr, error) {
+	return calc.ParseAddrRange(strings.Join(args, "-"))
+}
+
+// parsePrefixList parses prefixes separated by spaces, commas or newlines,
+// as pasted from a routing table or a change request.
+func parsePrefixList(text string) ([]netip.Prefix, error) {
+	fields := splitList(text)
+	if len(fields) == 0 {
+		return nil, calc.ErrEmptyInput
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(fields))
+
+	for _, field := range fields {
+		prefix, err := netip.ParsePrefix(field)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", field, calc.ErrInvalidAddress)
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes, nil
+}
+
+// parseACLTargets is like parsePrefixList, but also accepts single host
+// addresses and "start-end" ranges, which are split into prefixes.
+func parseACLTargets(text string) ([]netip.Prefix, error) {
+	fields := splitList(text)
+	if len(fields) == 0 {
+		return nil, calc.ErrEmptyInput
+	}
+
+	var prefixes []netip.Prefix
+
+	for _, field := range fields {
+		if prefix, err := netip.ParsePrefix(field); err == nil {
+			prefixes = append(prefixes, prefix)
+			continue
+		}
+
+		if host, err := netip.ParseAddr(field); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(host, host.BitLen()))
+			continue
+		}
+
+		start, end, err := calc.ParseAddrRange(field)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", field, err)
+		}
+
+		rangePrefixes, _, err := calc.RangeToCIDRs(start, end)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", field, err)
+		}
+
+		prefixes = append(prefixes, rangePrefixes...)
+	}
+
+	return prefixes, nil
+}
+
+func splitList(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+}
+
+// formatSupernetSteps numbers the steps of the aggregation followed by those
+// of the supernet and lists the extra prefixes the supernet would include.
+func formatSupernetSteps(aggregateSteps []string, supernetSteps []string, supernet netip.Prefix, extra []netip.Prefix) []string {
+	lines := formatNumberedSteps(append(append([]string(nil), aggregateSteps...), supernetSteps...))
+	lines = append(lines, "Supernet: "+supernet.String())
+
+	if len(extra) > 0 {
+		lines = append(lines, "Extra prefixes: "+joinPrefixes(extra, ", "))
+	}
+
+	return lines
+}
+
+// formatWildcardSteps shows a network mask above its wildcard mask in binary,
+// so that it is clear every bit has been flipped.
+func formatWildcardSteps(netMask netip.Addr, wildcard netip.Addr) []string {
+	return []string{
+		fmt.Sprintf("%-15s  %s", netMask, formatAddrBin(netMask)),
+		fmt.Sprintf("%-15s  %s", wildcard, formatAddrBin(wildcard)),
+		"Each bit of the wildcard mask is the inverse of the network mask: 1 bits are ignored when matching.",
+	}
+}
+
+func formatAddr(ipAddress netip.Addr, err error) string {
+	if err != nil {
+		return ""
+	}
+
+	return ipAddress.String()
+}
+
+// formatBitwiseTable lines up the operands and the result of a bitwise
+// operation in binary, hexadecimal and decimal columns.
+func formatBitwiseTable(op calc.BitwiseOperator, a *big.Int, b *big.Int, result *big.Int, width int) []string {
+	row := func(label string, value *big.Int) string {
+		return fmt.Sprintf("%-5s %s  0x%s  %s", label, calc.FormatBitwiseBin(value, width), calc.FormatBitwiseHex(value, width), value)
+	}
+
+	lines := []string{row("A", a)}
+
+	if op.Shift() {
+		lines = append(lines, fmt.Sprintf("%-5s by %s bits", op, b))
+	} else if !op.Unary() {
+		lines = append(lines, row("B", b))
+	}
+
+	return append(lines, row(op.String(), result))
+}
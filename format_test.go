@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/KhangBBBB/netcalc/calc"
+)
+
+func TestFormatSubnetDetailsBroadcast(t *testing.T) {
+	tests := []struct {
+		netMask       string
+		wantBroadcast string
+	}{
+		{"255.255.255.0", "Broadcast:        192.168.1.255"},
+		{"255.255.255.252", "Broadcast:        192.168.1.11"},
+		{"255.255.255.254", "Broadcast:        none (RFC 3021)"},
+		{"255.255.255.255", "Broadcast:        none (RFC 3021)"},
+	}
+
+	for _, test := range tests {
+		details, err := calc.DescribeSubnet(netip.MustParseAddr("192.168.1.10"), netip.MustParseAddr(test.netMask))
+		if err != nil {
+			t.Fatalf("DescribeSubnet(192.168.1.10, %s) error: %v", test.netMask, err)
+		}
+
+		lines := formatSubnetDetails(details)
+		if lines[1] != test.wantBroadcast {
+			t.Errorf("formatSubnetDetails(192.168.1.10 %s)[1] = %q, want %q", test.netMask, lines[1], test.wantBroadcast)
+		}
+	}
+}
+
+func TestFormatVLSMStepsBroadcast(t *testing.T) {
+	plan, steps, err := calc.PlanVLSM(netip.MustParsePrefix("10.0.0.0/28"), []calc.VLSMRequirement{
+		{Name: "LAN", Hosts: 3},
+		{Name: "Loopback", Hosts: 1},
+	})
+	if err != nil {
+		t.Fatalf("PlanVLSM error: %v", err)
+	}
+
+	lines := formatVLSMSteps(plan, steps, nil)
+	allocations := lines[len(lines)-len(plan.Allocations):]
+
+	if !strings.Contains(allocations[0], "broadcast 10.0.0.7 ") {
+		t.Errorf("LAN allocation = %q, want broadcast 10.0.0.7", allocations[0])
+	}
+
+	if !strings.Contains(allocations[1], "broadcast none (RFC 3021) ") {
+		t.Errorf("Loopback allocation = %q, want no broadcast", allocations[1])
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxHistoryEntries is the number of entries kept. Older entries are dropped
+// from the file the next time it is loaded.
+const maxHistoryEntries = 1000
+
+// historyEntry is a completed calculation. Inputs are the calculator state,
+// so the entry can be restored with Calculator.SetState.
+type historyEntry struct {
+	Time       time.Time         `json:"time"`
+	Calculator string            `json:"calculator"`
+	Inputs     map[string]string `json:"inputs"`
+	Outputs    []string          `json:"outputs,omitempty"`
+}
+
+// history is stored as JSON Lines, one entry per line, so that recording an
+// entry only appends to the file.
+type history struct {
+	path    string
+	Entries []historyEntry
+}
+
+// defaultHistoryPath returns the history file under the user config dir,
+// such as ~/.config/netcalc/history.jsonl on Linux.
+func defaultHistoryPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("defaultHistoryPath: %w", err)
+	}
+
+	return filepath.Join(configDir, "netcalc", "history.jsonl"), nil
+}
+
+// loadHistory reads the history file at path. A missing file is an empty
+// history, and lines that cannot be parsed are skipped rather than losing
+// the rest of the history.
+func loadHistory(path string) (*history, error) {
+	h := &history{path: path}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("loadHistory: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		var entry historyEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil && entry.Calculator != "" {
+			h.Entries = append(h.Entries, entry)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("loadHistory: %w", err)
+	}
+
+	if len(h.Entries) > maxHistoryEntries {
+		h.Entries = h.Entries[len(h.Entries)-maxHistoryEntries:]
+
+		if err := h.rewrite(); err != nil {
+			return nil, fmt.Errorf("loadHistory: %w", err)
+		}
+	}
+
+	return h, nil
+}
+
+// Add records entry, unless it has the same calculator and inputs as the
+// latest entry of that calculator.
+func (h *history) Add(entry historyEntry) error {
+	for i := len(h.Entries) - 1; i >= 0; i-- {
+		if h.Entries[i].Calculator == entry.Calculator {
+			if equalState(h.Entries[i].Inputs, entry.Inputs) {
+				return nil
+			}
+
+			break
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("history.Add: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return fmt.Errorf("history.Add: %w", err)
+	}
+
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("history.Add: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("history.Add: %w", err)
+	}
+
+	h.Entries = append(h.Entries, entry)
+
+	return nil
+}
+
+// Search returns the entries whose calculator, inputs or outputs contain
+// every word of query, ignoring case, newest first.
+func (h *history) Search(query string) []historyEntry {
+	words := strings.Fields(strings.ToLower(query))
+
+	var matches []historyEntry
+
+	for i := len(h.Entries) - 1; i >= 0; i-- {
+		text := strings.ToLower(h.Entries[i].searchText())
+		matched := true
+
+		for _, word := range words {
+			if !strings.Contains(text, word) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			matches = append(matches, h.Entries[i])
+		}
+	}
+
+	return matches
+}
+
+func (h *history) rewrite() error {
+	var buf strings.Builder
+
+	for _, entry := range h.Entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	temporaryPath := h.path + ".tmp"
+	if err := os.WriteFile(temporaryPath, []byte(buf.String()), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryPath, h.path)
+}
+
+// Summary is the one-line description shown in the history list: the
+// non-empty inputs in a stable order.
+func (entry historyEntry) Summary() string {
+	names := make([]string, 0, len(entry.Inputs))
+	for name, value := range entry.Inputs {
+		if value != "" && value != "false" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, name+"="+entry.Inputs[name])
+	}
+
+	return strings.Join(values, "  ")
+}
+
+func (entry historyEntry) searchText() string {
+	return entry.Calculator + "\n" + entry.Summary() + "\n" + strings.Join(entry.Outputs, "\n")
+}
+
+func equalState(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name, value := range a {
+		if other, ok := b[name]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -9,12 +9,16 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(RunCLI(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	application := NewApplication()
 
 	go func() {
 		w := app.NewWindow(
 			app.Title("netcalc v0.1.0"),
-			app.Size(unit.Dp(650), unit.Dp(380)),
+			app.Size(unit.Dp(900), unit.Dp(600)),
 		)
 		if err := application.Run(w); err != nil {
 			log.Println(err)
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var errInvalidSessionName = errors.New("session name must be non-empty and must not contain path separators")
+
+// session is the state of every calculator, keyed by calculator title, as
+// saved in a session file.
+type session struct {
+	Calculators map[string]map[string]string `json:"calculators"`
+}
+
+// sessionDir returns the directory of the session files under the user
+// config dir, next to the history.
+func sessionDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("sessionDir: %w", err)
+	}
+
+	return filepath.Join(configDir, "netcalc", "sessions"), nil
+}
+
+func sessionPath(name string) (string, error) {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" || strings.ContainsAny(trimmedName, `/\`) || trimmedName == "." || trimmedName == ".." {
+		return "", fmt.Errorf("sessionPath: %q: %w", name, errInvalidSessionName)
+	}
+
+	dir, err := sessionDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, trimmedName+".json"), nil
+}
+
+func saveSession(path string, s session) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("saveSession: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("saveSession: %w", err)
+	}
+
+	temporaryPath := path + ".tmp"
+	if err := os.WriteFile(temporaryPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("saveSession: %w", err)
+	}
+
+	if err := os.Rename(temporaryPath, path); err != nil {
+		return fmt.Errorf("saveSession: %w", err)
+	}
+
+	return nil
+}
+
+func loadSession(path string) (session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return session{}, fmt.Errorf("loadSession: %w", err)
+	}
+
+	var s session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return session{}, fmt.Errorf("loadSession: %w", err)
+	}
+
+	return s, nil
+}
+
+// listSessions returns the names of the saved sessions in alphabetical
+// order.
+func listSessions() ([]string, error) {
+	dir, err := sessionDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("listSessions: %w", err)
+	}
+
+	var names []string
+
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
+			names = append(names, strings.TrimSuffix(file.Name(), ".json"))
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
@@ -1,17 +1,26 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"image/color"
+	"math/big"
+	"net/netip"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/KhangBBBB/netcalc/calc"
 
 	"gioui.org/app"
 	"gioui.org/font/gofont"
 
 	"gioui.org/io/clipboard"
+	"gioui.org/io/key"
 	"gioui.org/io/system"
 	"gioui.org/layout"
 	"gioui.org/op"
+	"gioui.org/op/clip"
 	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
@@ -20,25 +29,75 @@ import (
 var padding1 = unit.Dp(8)
 var padding2 = unit.Dp(4)
 var padding3 = unit.Dp(2)
+var sidebarWidth = unit.Dp(150)
+var pasteResultHeight = unit.Dp(200)
+var sessionNameWidth = unit.Dp(160)
+
+// historyDelay is how long the inputs of a calculator must stay the same
+// before the calculation is recorded in the history.
+const historyDelay = 2 * time.Second
+
+// undoKeys are the shortcuts handled by the application. A focused field
+// handles Short-Z and Short-Shift-Z itself, undoing only its own text.
+const undoKeys = key.Set("Short-Z|Short-(Shift)-Y|Short-Shift-Z")
 
 type Application struct {
 	Theme *material.Theme
 
-	IPv4DecHexBinConverter    IPv4DecHexBinConverter
-	NetMaskCIDRSlashConverter NetMaskCIDRSlashConverter
-	NetAddrFinder             NetAddrFinder
-	IPInfoChecker             IPInfoChecker
-	DecHexBinConverter        DecHexBinConverter
-	ANDOperationOnTwoBins     ANDOperationOnTwoBins
+	Toolbar        Toolbar
+	PasteInspector PasteInspector
+
+	Categories []category
+	Navigation []widget.Clickable
+	Selected   int
+	Content    widget.List
+
+	// History is nil if it could not be loaded, for the reason in
+	// HistoryError.
+	History      *history
+	HistoryError error
+	HistoryView  HistoryView
+
+	// Undo holds the changes to the calculators, one transaction per user
+	// edit together with the fields it updated.
+	Undo    undoStack
+	tracked map[Calculator]trackedState
+}
+
+// category is a group of calculators shown together when it is selected in
+// the sidebar.
+type category struct {
+	Name        string
+	Calculators []Calculator
+}
+
+// trackedState is the last seen state of a calculator, for recording
+// changes to it for undo and in the history once it has stopped changing.
+type trackedState struct {
+	state     map[string]string
+	changedAt time.Time
+	recorded  bool
 }
 
 func NewApplication() *Application {
 	theme := material.NewTheme(gofont.Collection())
 	theme.TextSize = 12
 	application := Application{
-		Theme: theme,
+		Theme:      theme,
+		Categories: newCalculators(),
+		tracked:    make(map[Calculator]trackedState),
+	}
+
+	// The last button of the sidebar shows the history.
+	application.Navigation = make([]widget.Clickable, len(application.Categories)+1)
+
+	historyPath, err := defaultHistoryPath()
+	if err == nil {
+		application.History, err = loadHistory(historyPath)
 	}
 
+	application.HistoryError = err
+
 	return &application
 }
 
@@ -62,39 +121,356 @@ func (a *Application) Run(window *app.Window) error {
 }
 
 func (a *Application) Layout(gtx layout.Context) layout.Dimensions {
-	return layout.UniformInset(padding2).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-		return layout.UniformInset(padding3).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
-			spacer := layout.Rigid(layout.Spacer{Height: padding1}.Layout)
+	for _, e := range gtx.Events(a) {
+		if e, ok := e.(key.Event); ok && e.State == key.Press {
+			if e.Name == "Z" && !e.Modifiers.Contain(key.ModShift) {
+				a.undo()
+			} else {
+				a.redo()
+			}
+		}
+	}
+
+	for i := range a.Navigation {
+		if a.Navigation[i].Clicked() && a.Selected != i {
+			a.Selected = i
+			a.Content.Position = layout.Position{}
+		}
+	}
 
+	dims := layout.UniformInset(padding2).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+		return layout.UniformInset(padding3).Layout(gtx, func(gtx layout.Context) layout.Dimensions {
 			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-				layout.Rigid(Heading(a.Theme, "Convert between IPv4 dot decimal, hexadecimal, and binary formats").Layout),
-				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return a.IPv4DecHexBinConverter.Layout(a.Theme, gtx)
-				}),
-				spacer,
-				layout.Rigid(Heading(a.Theme, "Convert between network mask and CIDR slash value:").Layout),
-				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return a.NetMaskCIDRSlashConverter.Layout(a.Theme, gtx)
-				}),
-				spacer,
-				layout.Rigid(Heading(a.Theme, "Compute network address from host IP address and network mask:").Layout),
-				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return a.NetAddrFinder.Layout(a.Theme, gtx)
-				}),
-				spacer,
-				layout.Rigid(Heading(a.Theme, "Is IP address private/loopback/link-local unicast/multicast?").Layout),
-				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return a.IPInfoChecker.Layout(a.Theme, gtx)
+				layout.Rigid(a.layoutToolbar),
+				layout.Rigid(layout.Spacer{Height: padding2}.Layout),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					return a.PasteInspector.Layout(a.Theme, gtx)
 				}),
-				spacer,
-				layout.Rigid(Heading(a.Theme, "Convert between decimal, hexadecimal, and binary formats:").Layout),
+				layout.Rigid(layout.Spacer{Height: padding1}.Layout),
 				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return a.DecHexBinConverter.Layout(a.Theme, gtx)
+					return layout.Flex{}.Layout(gtx,
+						layout.Rigid(a.layoutSidebar),
+						layout.Rigid(layout.Spacer{Width: padding1}.Layout),
+						layout.Flexed(1, a.layoutContent),
+					)
 				}),
-				spacer,
-				layout.Rigid(Heading(a.Theme, "Perform AND operation on two binary numbers:").Layout),
-				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-					return a.ANDOperationOnTwoBins.Layout(a.Theme, gtx)
+			)
+		})
+	})
+
+	// Added last, so that the shortcuts reach the application before any
+	// field that does not have the focus.
+	area := clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops)
+	key.InputOp{Tag: a, Keys: undoKeys}.Add(gtx.Ops)
+	area.Pop()
+
+	a.trackChanges(gtx)
+
+	return dims
+}
+
+// trackChanges records every change to the state of a calculator for undo,
+// and adds the calculation to the history once the inputs have stayed the
+// same for historyDelay, so that typing is not recorded one keystroke at a
+// time. Incomplete or invalid input is not recorded in the history, and
+// neither is the state a calculator starts with.
+func (a *Application) trackChanges(gtx layout.Context) {
+	for _, category := range a.Categories {
+		for _, calculator := range category.Calculators {
+			state := calculator.State()
+
+			tracked, ok := a.tracked[calculator]
+			if !ok {
+				a.tracked[calculator] = trackedState{state: state, recorded: true}
+				continue
+			}
+
+			if !equalState(state, tracked.state) {
+				a.Undo.RecordEdit(stateChange{Calculator: calculator, Before: tracked.state, After: state}, gtx.Now)
+				a.tracked[calculator] = trackedState{state: state, changedAt: gtx.Now}
+				op.InvalidateOp{At: gtx.Now.Add(historyDelay)}.Add(gtx.Ops)
+
+				continue
+			}
+
+			if a.History == nil || tracked.recorded || gtx.Now.Sub(tracked.changedAt) < historyDelay {
+				continue
+			}
+
+			tracked.recorded = true
+			a.tracked[calculator] = tracked
+
+			entry := historyEntry{
+				Time:       gtx.Now,
+				Calculator: calculator.Title(),
+				Inputs:     state,
+				Outputs:    calculatorOutputs(calculator),
+			}
+
+			if entry.Summary() == "" || hasInvalidInput(calculator) {
+				continue
+			}
+
+			if err := a.History.Add(entry); err != nil {
+				a.HistoryError = err
+			}
+		}
+	}
+}
+
+// setCalculatorState restores state into calculator without recording it as
+// an edit, and returns the change so that it can be undone.
+func (a *Application) setCalculatorState(calculator Calculator, state map[string]string) stateChange {
+	before := calculator.State()
+	calculator.SetState(state)
+	after := calculator.State()
+
+	a.tracked[calculator] = trackedState{state: after, recorded: true}
+
+	return stateChange{Calculator: calculator, Before: before, After: after}
+}
+
+// showCalculator selects the category of calculator and scrolls to it.
+func (a *Application) showCalculator(calculator Calculator) {
+	for i, category := range a.Categories {
+		for j, c := range category.Calculators {
+			if c == calculator {
+				a.Selected = i
+				a.Content.Position = layout.Position{First: j}
+
+				return
+			}
+		}
+	}
+}
+
+// findCalculator returns the calculator with the given title, or nil.
+func (a *Application) findCalculator(title string) Calculator {
+	for _, category := range a.Categories {
+		for _, calculator := range category.Calculators {
+			if calculator.Title() == title {
+				return calculator
+			}
+		}
+	}
+
+	return nil
+}
+
+// restoreHistory puts the inputs of entry back into the calculator it came
+// from and scrolls to it.
+func (a *Application) restoreHistory(entry historyEntry, now time.Time) {
+	calculator := a.findCalculator(entry.Calculator)
+	if calculator == nil {
+		a.HistoryError = fmt.Errorf("%q is no longer available", entry.Calculator)
+		return
+	}
+
+	a.Undo.Record(transaction{Changes: []stateChange{a.setCalculatorState(calculator, entry.Inputs)}, At: now})
+	a.showCalculator(calculator)
+}
+
+// undo reverts the latest transaction and shows the calculator it changed.
+func (a *Application) undo() {
+	t, ok := a.Undo.Undo()
+	if !ok {
+		return
+	}
+
+	for i := len(t.Changes) - 1; i >= 0; i-- {
+		a.setCalculatorState(t.Changes[i].Calculator, t.Changes[i].Before)
+	}
+
+	if len(t.Changes) == 1 {
+		a.showCalculator(t.Changes[0].Calculator)
+	}
+}
+
+// redo reapplies the latest undone transaction.
+func (a *Application) redo() {
+	t, ok := a.Undo.Redo()
+	if !ok {
+		return
+	}
+
+	for _, change := range t.Changes {
+		a.setCalculatorState(change.Calculator, change.After)
+	}
+
+	if len(t.Changes) == 1 {
+		a.showCalculator(t.Changes[0].Calculator)
+	}
+}
+
+// saveSession writes the state of every calculator to the named session.
+func (a *Application) saveSession(name string) error {
+	path, err := sessionPath(name)
+	if err != nil {
+		return err
+	}
+
+	s := session{Calculators: make(map[string]map[string]string)}
+
+	for _, category := range a.Categories {
+		for _, calculator := range category.Calculators {
+			s.Calculators[calculator.Title()] = calculator.State()
+		}
+	}
+
+	return saveSession(path, s)
+}
+
+// loadSession restores every calculator saved in the named session, as one
+// transaction. Calculators missing from the session are left as they are.
+func (a *Application) loadSession(name string, now time.Time) error {
+	path, err := sessionPath(name)
+	if err != nil {
+		return err
+	}
+
+	s, err := loadSession(path)
+	if err != nil {
+		return err
+	}
+
+	var changes []stateChange
+
+	for _, category := range a.Categories {
+		for _, calculator := range category.Calculators {
+			state, ok := s.Calculators[calculator.Title()]
+			if !ok {
+				continue
+			}
+
+			if change := a.setCalculatorState(calculator, state); !equalState(change.Before, change.After) {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	if len(changes) > 0 {
+		a.Undo.Record(transaction{Changes: changes, At: now})
+	}
+
+	return nil
+}
+
+func (a *Application) layoutToolbar(gtx layout.Context) layout.Dimensions {
+	toolbar := &a.Toolbar
+
+	if toolbar.Undo.Clicked() {
+		a.undo()
+	}
+
+	if toolbar.Redo.Clicked() {
+		a.redo()
+	}
+
+	name := strings.TrimSpace(toolbar.SessionName.Text())
+
+	if toolbar.Save.Clicked() {
+		toolbar.Status = fmt.Sprintf("Saved session %q", name)
+		if err := a.saveSession(name); err != nil {
+			toolbar.Status = err.Error()
+		}
+	}
+
+	if toolbar.Load.Clicked() {
+		toolbar.Status = fmt.Sprintf("Loaded session %q", name)
+
+		if name == "" {
+			names, err := listSessions()
+			toolbar.Status = "Saved sessions: " + strings.Join(names, ", ")
+
+			if err != nil {
+				toolbar.Status = err.Error()
+			} else if len(names) == 0 {
+				toolbar.Status = "No saved sessions"
+			}
+		} else if err := a.loadSession(name, gtx.Now); err != nil {
+			toolbar.Status = err.Error()
+		}
+	}
+
+	return toolbar.Layout(a.Theme, gtx, a.Undo.CanUndo(), a.Undo.CanRedo())
+}
+
+func (a *Application) layoutHistory(gtx layout.Context) layout.Dimensions {
+	view := &a.HistoryView
+
+	var entries []historyEntry
+	if a.History != nil {
+		entries = a.History.Entries
+	}
+
+	if view.Search.Changed() || view.count != len(entries) {
+		view.count = len(entries)
+		view.matches = nil
+
+		if a.History != nil {
+			view.matches = a.History.Search(view.Search.Text())
+		}
+
+		view.Restore = make([]widget.Clickable, len(view.matches))
+	}
+
+	for i := range view.Restore {
+		if view.Restore[i].Clicked() {
+			a.restoreHistory(view.matches[i], gtx.Now)
+		}
+	}
+
+	return view.Layout(a.Theme, gtx, a.HistoryError)
+}
+
+func (a *Application) layoutSidebar(gtx layout.Context) layout.Dimensions {
+	gtx.Constraints.Min.X = gtx.Dp(sidebarWidth)
+	gtx.Constraints.Max.X = gtx.Constraints.Min.X
+
+	names := make([]string, 0, len(a.Navigation))
+	for _, category := range a.Categories {
+		names = append(names, category.Name)
+	}
+
+	names = append(names, "History")
+
+	buttons := make([]layout.FlexChild, 0, len(names)*2)
+
+	for i, name := range names {
+		button := material.Button(a.Theme, &a.Navigation[i], name)
+		if i != a.Selected {
+			button.Background = color.NRGBA{A: 0x18}
+			button.Color = a.Theme.Palette.Fg
+		}
+
+		buttons = append(buttons,
+			layout.Rigid(button.Layout),
+			layout.Rigid(layout.Spacer{Height: padding2}.Layout),
+		)
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, buttons...)
+}
+
+// layoutContent lists the calculators of the selected category. Each gets
+// its fixed height, since its own layout fills whatever space it is given.
+func (a *Application) layoutContent(gtx layout.Context) layout.Dimensions {
+	if a.Selected == len(a.Categories) {
+		return a.layoutHistory(gtx)
+	}
+
+	a.Content.Axis = layout.Vertical
+	calculators := a.Categories[a.Selected].Calculators
+
+	return material.List(a.Theme, &a.Content).Layout(gtx, len(calculators), func(gtx layout.Context, i int) layout.Dimensions {
+		return layout.Inset{Right: padding1, Bottom: padding1}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(Heading(a.Theme, calculators[i].Title()+":").Layout),
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					gtx.Constraints.Min.Y = gtx.Dp(calculators[i].Height())
+					gtx.Constraints.Max.Y = gtx.Constraints.Min.Y
+
+					return calculators[i].Layout(a.Theme, gtx)
 				}),
 			)
 		})
@@ -102,48 +478,50 @@ func (a *Application) Layout(gtx layout.Context) layout.Dimensions {
 }
 
 type IPv4DecHexBinConverter struct {
-	Dec Field
-	Hex Field
-	Bin Field
+	Dec Field `state:"Dec"`
+	Hex Field `state:"Hex"`
+	Bin Field `state:"Bin"`
 }
 
 func (conv *IPv4DecHexBinConverter) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
 	if conv.Dec.Changed() {
-		hexValue, err := IPv4ToHexFormat(conv.Dec.Text())
-		conv.Dec.Invalid = err != nil
+		ipv4Value, err := calc.ParseIPv4(conv.Dec.Text())
+
+		hexValue, _ := calc.IPv4ToHexFormat(ipv4Value)
 		conv.Hex.SetText(hexValue)
 
-		binValue, err := IPv4ToBinFormat(conv.Dec.Text())
+		binValue, _ := calc.IPv4ToBinFormat(ipv4Value)
+		conv.Bin.SetText(binValue)
+
 		conv.Dec.Invalid = err != nil
-		conv.Bin.SetText(FormatBinInNimbles(binValue))
 	}
 
 	if conv.Hex.Changed() {
-		ipv4Value, err := HexToIPv4Format(conv.Hex.Text())
+		ipv4Value, err := calc.HexToIPv4Format(conv.Hex.Text())
 		conv.Hex.Invalid = err != nil
 
 		if conv.Hex.Invalid {
 			conv.Dec.SetText("")
 			conv.Bin.SetText("")
 		} else {
-			conv.Dec.SetText(ipv4Value)
+			conv.Dec.SetText(ipv4Value.String())
 
-			binValue, _ := IPv4ToBinFormat(ipv4Value)
+			binValue, _ := calc.IPv4ToBinFormat(ipv4Value)
 			conv.Bin.SetText(binValue)
 		}
 	}
 
 	if conv.Bin.Changed() {
-		ipv4Value, err := BinToIPv4Format(conv.Bin.Text())
+		ipv4Value, err := calc.BinToIPv4Format(conv.Bin.Text())
 		conv.Bin.Invalid = err != nil
 
 		if conv.Bin.Invalid {
 			conv.Dec.SetText("")
 			conv.Hex.SetText("")
 		} else {
-			conv.Dec.SetText(ipv4Value)
+			conv.Dec.SetText(ipv4Value.String())
 
-			hexValue, _ := IPv4ToHexFormat(ipv4Value)
+			hexValue, _ := calc.IPv4ToHexFormat(ipv4Value)
 			conv.Hex.SetText(hexValue)
 		}
 	}
@@ -172,55 +550,92 @@ func (conv *IPv4DecHexBinConverter) Layout(th *material.Theme, gtx layout.Contex
 }
 
 type NetMaskCIDRSlashConverter struct {
-	NetMask   Field
-	CIDRSlash Field
+	NetMask   Field `state:"NetMask"`
+	CIDRSlash Field `state:"CIDRSlash"`
+	Problem   StepList
 }
 
 func (conv *NetMaskCIDRSlashConverter) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
 	if conv.NetMask.Changed() {
-		cidrSlashValue, err := NetworkMaskToCIDRSlashValue(conv.NetMask.Text())
+		cidrSlashValue, err := parseNetworkMask(conv.NetMask.Text())
 		conv.NetMask.Invalid = err != nil
 		conv.CIDRSlash.SetText(cidrSlashValue)
+		conv.Problem.Steps = nil
+
+		if mask, err := netip.ParseAddr(strings.TrimSpace(conv.NetMask.Text())); err == nil {
+			conv.Problem.Steps = formatMaskProblem(mask)
+		}
 	}
 
 	if conv.CIDRSlash.Changed() {
-		netMaskValue, err := CIDRSlashValueToNetworkMask(conv.CIDRSlash.Text())
+		netMaskValue, err := parseCIDRSlashValue(conv.CIDRSlash.Text(), 32)
 		conv.CIDRSlash.Invalid = err != nil
+		conv.Problem.Steps = nil
 		conv.NetMask.SetText(netMaskValue)
 	}
 
 	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
 
-	return layout.Flex{}.Layout(gtx,
-		layout.Rigid(material.Body1(th, "Network mask:").Layout),
-		spacer,
-		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return conv.NetMask.Layout(th, gtx)
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Network mask:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.NetMask.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "CIDR slash value:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.CIDRSlash.Layout(th, gtx)
+				}),
+			)
 		}),
-		spacer,
-		layout.Rigid(material.Body1(th, "CIDR slash value:").Layout),
-		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return conv.CIDRSlash.Layout(th, gtx)
+			return conv.Problem.Layout(th, gtx)
 		}),
 	)
 }
 
 type NetAddrFinder struct {
-	HostIP       Field
-	NetMask      Field
+	HostIP       Field `state:"HostIP"`
+	NetMask      Field `state:"NetMask"`
 	NetAddr      widget.Clickable
 	NetAddrValue string
+	Details      StepList
+	Bitmask      widget.Bool `state:"Bitmask"`
 }
 
 func (finder *NetAddrFinder) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
-	if finder.HostIP.Changed() || finder.NetMask.Changed() {
-		var err error
+	hostIPChanged := finder.HostIP.Changed()
+	netMaskChanged := finder.NetMask.Changed()
+	bitmaskChanged := finder.Bitmask.Changed()
+
+	if hostIPChanged || netMaskChanged || bitmaskChanged {
+		finder.NetAddrValue = ""
+		finder.Details.Steps = nil
+
+		if finder.Bitmask.Value {
+			finder.applyBitmask()
+		} else if finder.HostIP.Text() != "" {
+			var details calc.SubnetDetails
+
+			prefix, err := parseHostPrefix(finder.HostIP.Text(), finder.NetMask.Text())
+			if err == nil {
+				details, err = describeHostPrefix(prefix)
+			}
 
-		if finder.HostIP.Text() != "" && finder.NetMask.Text() != "" {
-			finder.NetAddrValue, err = FindNetworkAddress(finder.HostIP.Text(), finder.NetMask.Text())
-			finder.HostIP.Invalid = err != nil
-			finder.NetMask.Invalid = err != nil
+			finder.HostIP.Invalid, finder.NetMask.Invalid = hostPrefixInvalid(finder.HostIP.Text(), finder.NetMask.Text(), err)
+
+			if err == nil {
+				finder.NetAddrValue = details.Prefix.String()
+				finder.Details.Steps = formatSubnetDetails(details)
+			} else if mask, maskErr := netip.ParseAddr(strings.TrimSpace(finder.NetMask.Text())); maskErr == nil {
+				if problem := formatMaskProblem(mask); problem != nil {
+					finder.Details.Steps = append(problem, "Tick \"Arbitrary bitmask\" to AND with it anyway.")
+				}
+			}
 		}
 	}
 
@@ -230,237 +645,1631 @@ func (finder *NetAddrFinder) Layout(th *material.Theme, gtx layout.Context) layo
 
 	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
 
-	return layout.Flex{}.Layout(gtx,
-		layout.Rigid(material.Body1(th, "Host IP:").Layout),
-		spacer,
-		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return finder.HostIP.Layout(th, gtx)
-		}),
-		spacer,
-		layout.Rigid(material.Body1(th, "Network mask:").Layout),
-		spacer,
-		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return finder.NetMask.Layout(th, gtx)
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Host IP:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return finder.HostIP.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "Network mask:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return finder.NetMask.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.CheckBox(th, &finder.Bitmask, "Arbitrary bitmask").Layout),
+				spacer,
+				layout.Rigid(material.Body1(th, "Network address:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return material.Clickable(gtx, &finder.NetAddr, material.Body1(th, finder.NetAddrValue).Layout)
+				}),
+			)
 		}),
-		spacer,
-		layout.Rigid(material.Body1(th, "Network address:").Layout),
-		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return material.Clickable(gtx, &finder.NetAddr, material.Body1(th, finder.NetAddrValue).Layout)
+			return finder.Details.Layout(th, gtx)
 		}),
 	)
 }
 
-type IPInfoChecker struct {
-	IPAddr                       Field
-	PrivateChecked               widget.Clickable
-	PrivateCheckedValue          bool
-	LoopbackChecked              widget.Clickable
-	LoopbackCheckedValue         bool
-	LinkLocalUnicastChecked      widget.Clickable
-	LinkLocalUnicastCheckedValue bool
-	MulticastChecked             widget.Clickable
-	MulticastCheckedValue        bool
-}
+// applyBitmask ANDs the host with the mask field taken as an arbitrary,
+// possibly non-contiguous, bitmask.
+func (finder *NetAddrFinder) applyBitmask() {
+	if finder.HostIP.Text() == "" || finder.NetMask.Text() == "" {
+		return
+	}
 
-func (checker *IPInfoChecker) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
-	var privateChecked, loopbackChecked, linklocalUnicastChecked, multicastChecked string
+	hostIP, hostErr := netip.ParseAddr(strings.TrimSpace(finder.HostIP.Text()))
+	bitmask, maskErr := netip.ParseAddr(strings.TrimSpace(finder.NetMask.Text()))
+	finder.HostIP.Invalid = hostErr != nil
+	finder.NetMask.Invalid = maskErr != nil
 
-	if checker.IPAddr.Changed() {
-		var err error
+	if hostErr != nil || maskErr != nil {
+		return
+	}
 
-		checker.PrivateCheckedValue, err = IsPrivateIP(checker.IPAddr.Text())
-		checker.IPAddr.Invalid = err != nil
+	result, err := calc.ApplyBitmask(hostIP, bitmask)
+	finder.NetMask.Invalid = err != nil
 
-		checker.LoopbackCheckedValue, err = IsLoopbackIP(checker.IPAddr.Text())
-		checker.IPAddr.Invalid = err != nil
+	if err == nil {
+		finder.NetAddrValue = result.String()
+		finder.Details.Steps = formatBitmaskSteps(hostIP, bitmask, result)
+	}
+}
 
-		checker.LinkLocalUnicastCheckedValue, err = IsLinkLocalUnicastIP(checker.IPAddr.Text())
-		checker.IPAddr.Invalid = err != nil
+type IPv6HexBinConverter struct {
+	Addr Field `state:"Addr"`
+	Hex  Field `state:"Hex"`
+	Bin  Field `state:"Bin"`
+}
 
-		checker.MulticastCheckedValue, err = IsMulticastIP(checker.IPAddr.Text())
-		checker.IPAddr.Invalid = err != nil
+func (conv *IPv6HexBinConverter) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	if conv.Addr.Changed() {
+		ipv6Value, err := calc.ParseIPv6(conv.Addr.Text())
+
+		hexValue, _ := calc.IPv6ToHexFormat(ipv6Value)
+		conv.Hex.SetText(hexValue)
+
+		binValue, _ := calc.IPv6ToBinFormat(ipv6Value)
+		conv.Bin.SetText(binValue)
+
+		conv.Addr.Invalid = err != nil
 	}
 
-	if checker.IPAddr.Text() != "" {
-		privateChecked = strconv.FormatBool(checker.PrivateCheckedValue)
-		loopbackChecked = strconv.FormatBool(checker.LoopbackCheckedValue)
-		linklocalUnicastChecked = strconv.FormatBool(checker.LinkLocalUnicastCheckedValue)
-		multicastChecked = strconv.FormatBool(checker.MulticastCheckedValue)
+	if conv.Hex.Changed() {
+		ipv6Value, err := calc.HexToIPv6Format(conv.Hex.Text())
+		conv.Hex.Invalid = err != nil
+
+		if conv.Hex.Invalid {
+			conv.Addr.SetText("")
+			conv.Bin.SetText("")
+		} else {
+			conv.Addr.SetText(ipv6Value.String())
+
+			binValue, _ := calc.IPv6ToBinFormat(ipv6Value)
+			conv.Bin.SetText(binValue)
+		}
 	}
 
-	if checker.PrivateChecked.Clicked() {
-		clipboard.WriteOp{Text: privateChecked}.Add(gtx.Ops)
-	} else if checker.LoopbackChecked.Clicked() {
-		clipboard.WriteOp{Text: loopbackChecked}.Add(gtx.Ops)
-	} else if checker.LinkLocalUnicastChecked.Clicked() {
-		clipboard.WriteOp{Text: linklocalUnicastChecked}.Add(gtx.Ops)
-	} else if checker.MulticastChecked.Clicked() {
-		clipboard.WriteOp{Text: multicastChecked}.Add(gtx.Ops)
+	if conv.Bin.Changed() {
+		ipv6Value, err := calc.BinToIPv6Format(conv.Bin.Text())
+		conv.Bin.Invalid = err != nil
+
+		if conv.Bin.Invalid {
+			conv.Addr.SetText("")
+			conv.Hex.SetText("")
+		} else {
+			conv.Addr.SetText(ipv6Value.String())
+
+			hexValue, _ := calc.IPv6ToHexFormat(ipv6Value)
+			conv.Hex.SetText(hexValue)
+		}
 	}
 
 	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
 
 	return layout.Flex{}.Layout(gtx,
-		layout.Rigid(material.Body1(th, "IP:").Layout),
-		spacer,
-		layout.Flexed(3, func(gtx layout.Context) layout.Dimensions {
-			return checker.IPAddr.Layout(th, gtx)
-		}),
-		spacer,
-		layout.Rigid(material.Body1(th, "Private?").Layout),
+		layout.Rigid(material.Body1(th, "IPv6:").Layout),
 		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return material.Clickable(gtx, &checker.PrivateChecked, material.Body1(th, privateChecked).Layout)
+			return conv.Addr.Layout(th, gtx)
 		}),
 		spacer,
-		layout.Rigid(material.Body1(th, "Loopback?").Layout),
+		layout.Rigid(material.Body1(th, "Hex:").Layout),
 		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return material.Clickable(gtx, &checker.LoopbackChecked, material.Body1(th, loopbackChecked).Layout)
+			return conv.Hex.Layout(th, gtx)
 		}),
 		spacer,
-		layout.Rigid(material.Body1(th, "Link-local unicast?").Layout),
+		layout.Rigid(material.Body1(th, "Bin:").Layout),
 		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return material.Clickable(gtx, &checker.LinkLocalUnicastChecked, material.Body1(th, linklocalUnicastChecked).Layout)
+			return conv.Bin.Layout(th, gtx)
+		}),
+	)
+}
+
+type IPv6MaskCIDRSlashConverter struct {
+	Mask      Field `state:"Mask"`
+	CIDRSlash Field `state:"CIDRSlash"`
+	Problem   StepList
+}
+
+func (conv *IPv6MaskCIDRSlashConverter) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	if conv.Mask.Changed() {
+		cidrSlashValue, err := parseNetworkMask(conv.Mask.Text())
+		conv.Mask.Invalid = err != nil
+		conv.CIDRSlash.SetText(cidrSlashValue)
+		conv.Problem.Steps = nil
+
+		if mask, err := netip.ParseAddr(strings.TrimSpace(conv.Mask.Text())); err == nil {
+			conv.Problem.Steps = formatMaskProblem(mask)
+		}
+	}
+
+	if conv.CIDRSlash.Changed() {
+		maskValue, err := parseCIDRSlashValue(conv.CIDRSlash.Text(), 128)
+		conv.CIDRSlash.Invalid = err != nil
+		conv.Problem.Steps = nil
+		conv.Mask.SetText(maskValue)
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "IPv6 mask:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.Mask.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "CIDR slash value:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.CIDRSlash.Layout(th, gtx)
+				}),
+			)
 		}),
-		spacer,
-		layout.Rigid(material.Body1(th, "Multicast?").Layout),
-		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return material.Clickable(gtx, &checker.MulticastChecked, material.Body1(th, multicastChecked).Layout)
+			return conv.Problem.Layout(th, gtx)
 		}),
 	)
 }
 
-type DecHexBinConverter struct {
-	Dec Field
-	Hex Field
-	Bin Field
+type IPv6NetAddrFinder struct {
+	HostIP       Field `state:"HostIP"`
+	Prefix       Field `state:"Prefix"`
+	NetAddr      widget.Clickable
+	NetAddrValue string
 }
 
-func (conv *DecHexBinConverter) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
-	if conv.Dec.Changed() {
-		trimmedDec := strings.TrimSpace(conv.Dec.Text())
+func (finder *IPv6NetAddrFinder) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	hostIPChanged := finder.HostIP.Changed()
+	prefixChanged := finder.Prefix.Changed()
 
-		hexValue, err := DecToHex(trimmedDec)
-		conv.Dec.Invalid = err != nil
-		conv.Hex.SetText(hexValue)
+	if hostIPChanged || prefixChanged {
+		finder.NetAddrValue = ""
 
-		binValue, err := DecToBin(trimmedDec)
-		conv.Dec.Invalid = err != nil
-		conv.Bin.SetText(FormatBinInNimbles(binValue))
-	}
+		if finder.HostIP.Text() != "" {
+			prefix, err := parseHostPrefix(finder.HostIP.Text(), finder.Prefix.Text())
+			if err == nil && !prefix.Addr().Is6() {
+				err = calc.ErrNotIPv6
+			}
 
-	if conv.Hex.Changed() {
-		decValue, err := strconv.ParseInt(strings.ReplaceAll(conv.Hex.Text(), " ", ""), 16, 64)
-		conv.Hex.Invalid = err != nil
+			finder.HostIP.Invalid, finder.Prefix.Invalid = hostPrefixInvalid(finder.HostIP.Text(), finder.Prefix.Text(), err)
 
-		if conv.Hex.Invalid {
-			conv.Dec.SetText("")
-			conv.Bin.SetText("")
-		} else {
-			conv.Dec.SetText(fmt.Sprintf("%d", decValue))
-			conv.Bin.SetText(FormatBinInNimbles(fmt.Sprintf("%b", decValue)))
+			if err == nil {
+				finder.NetAddrValue = prefix.Masked().String()
+			}
 		}
 	}
 
-	if conv.Bin.Changed() {
-		decValue, err := strconv.ParseInt(strings.ReplaceAll(conv.Bin.Text(), " ", ""), 2, 64)
-		conv.Bin.Invalid = err != nil
-
-		if conv.Bin.Invalid {
-			conv.Dec.SetText("")
-			conv.Hex.SetText("")
-		} else {
-			conv.Dec.SetText(fmt.Sprintf("%d", decValue))
-			conv.Hex.SetText(fmt.Sprintf("%X", decValue))
-		}
+	if finder.NetAddr.Clicked() {
+		clipboard.WriteOp{Text: finder.NetAddrValue}.Add(gtx.Ops)
 	}
 
 	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
 
 	return layout.Flex{}.Layout(gtx,
-		layout.Rigid(material.Body1(th, "Dec:").Layout),
+		layout.Rigid(material.Body1(th, "Host IPv6:").Layout),
 		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return conv.Dec.Layout(th, gtx)
+			return finder.HostIP.Layout(th, gtx)
 		}),
 		spacer,
-		layout.Rigid(material.Body1(th, "Hex:").Layout),
+		layout.Rigid(material.Body1(th, "Prefix or mask:").Layout),
 		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return conv.Hex.Layout(th, gtx)
+			return finder.Prefix.Layout(th, gtx)
 		}),
 		spacer,
-		layout.Rigid(material.Body1(th, "Bin:").Layout),
+		layout.Rigid(material.Body1(th, "Network address:").Layout),
 		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return conv.Bin.Layout(th, gtx)
+			return material.Clickable(gtx, &finder.NetAddr, material.Body1(th, finder.NetAddrValue).Layout)
 		}),
 	)
 }
 
-type ANDOperationOnTwoBins struct {
-	Bin1        Field
-	Bin2        Field
-	Result      widget.Clickable
-	ResultValue string
+type IPInfoChecker struct {
+	IPAddr Field `state:"IPAddr"`
+	Result StepList
 }
 
-func (conv *ANDOperationOnTwoBins) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
-	var err error
+func (checker *IPInfoChecker) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	if checker.IPAddr.Changed() {
+		checker.Result.Steps = nil
 
-	var dec1, dec2 int64
+		ipAddr, err := netip.ParseAddr(strings.TrimSpace(checker.IPAddr.Text()))
+		checker.IPAddr.Invalid = err != nil
 
-	if conv.Bin1.Changed() || conv.Bin2.Changed() {
-		conv.ResultValue = ""
-		trimmedBin1 := strings.ReplaceAll(conv.Bin1.Text(), " ", "")
-		trimmedBin2 := strings.ReplaceAll(conv.Bin2.Text(), " ", "")
+		if err == nil {
+			blocks, _ := calc.LookupSpecialPurpose(ipAddr)
+			checker.Result.Steps = formatSpecialPurposeBlocks(ipAddr, blocks)
+		}
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "IP:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return checker.IPAddr.Layout(th, gtx)
+				}),
+			)
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return checker.Result.Layout(th, gtx)
+		}),
+	)
+}
 
-		dec1, err = strconv.ParseInt(trimmedBin1, 2, 64)
-		conv.Bin1.Invalid = err != nil
+const converterArbitraryWidth = "any"
 
-		dec2, err = strconv.ParseInt(trimmedBin2, 2, 64)
-		conv.Bin2.Invalid = err != nil
+type DecHexBinConverter struct {
+	Dec    Field       `state:"Dec"`
+	Hex    Field       `state:"Hex"`
+	Oct    Field       `state:"Oct"`
+	Bin    Field       `state:"Bin"`
+	Radix  Field       `state:"Radix"`
+	Other  Field       `state:"Other"`
+	Width  widget.Enum `state:"Width"`
+	Signed widget.Bool `state:"Signed"`
+
+	value  *big.Int
+	source *Field
+	status string
+}
 
-		if conv.Bin1.Text() != "" && conv.Bin2.Text() != "" {
-			if !conv.Bin1.Invalid && !conv.Bin2.Invalid {
-				maxBinLength := len(trimmedBin1)
-				if len(trimmedBin2) > len(trimmedBin1) {
-					maxBinLength = len(trimmedBin2)
-				}
+type converterColumn struct {
+	field *Field
+	radix int
+}
 
-				binNum := fmt.Sprintf(fmt.Sprintf("%%0%db", maxBinLength), dec1&dec2)
-				conv.ResultValue = FormatBinInNimbles(binNum)
-			}
+func (conv *DecHexBinConverter) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	columns := conv.columns()
+
+	for _, column := range columns {
+		if column.field.Changed() {
+			conv.source = column.field
+			conv.convert(columns)
 		}
 	}
 
-	if conv.Result.Clicked() {
-		clipboard.WriteOp{Text: conv.ResultValue}.Add(gtx.Ops)
+	widthChanged := conv.Width.Changed()
+	signedChanged := conv.Signed.Changed()
+
+	if (widthChanged || signedChanged) && conv.source != nil {
+		conv.convert(columns)
+	}
+
+	if conv.Radix.Changed() {
+		conv.Radix.Invalid = conv.otherRadix() == 0
+
+		if conv.source == &conv.Other {
+			conv.convert(columns)
+		} else {
+			conv.Other.SetText(conv.format(conv.otherRadix()))
+		}
 	}
 
 	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
 
-	return layout.Flex{}.Layout(gtx,
-		layout.Rigid(material.Body1(th, "First:").Layout),
+	widths := []layout.FlexChild{
+		layout.Rigid(material.Body1(th, "Width:").Layout),
+		layout.Rigid(material.RadioButton(th, &conv.Width, converterArbitraryWidth, "Any").Layout),
+	}
+
+	for _, width := range calc.BitwiseWidths {
+		widths = append(widths, layout.Rigid(material.RadioButton(th, &conv.Width, strconv.Itoa(width), strconv.Itoa(width)).Layout))
+	}
+
+	widths = append(widths,
+		spacer,
+		layout.Rigid(material.CheckBox(th, &conv.Signed, "Signed (two's complement)").Layout),
 		spacer,
+		layout.Rigid(material.Body1(th, conv.status).Layout),
+	)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx, widths...)
+		}),
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return conv.Bin1.Layout(th, gtx)
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Dec:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.Dec.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "Hex:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.Hex.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "Oct:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.Oct.Layout(th, gtx)
+				}),
+			)
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Bin:").Layout),
+				spacer,
+				layout.Flexed(2, func(gtx layout.Context) layout.Dimensions {
+					return conv.Bin.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "Base:").Layout),
+				spacer,
+				layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+					gtx.Constraints.Max.X = gtx.Dp(unit.Dp(40))
+					return conv.Radix.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.Other.Layout(th, gtx)
+				}),
+			)
+		}),
+	)
+}
+
+func (conv *DecHexBinConverter) columns() []converterColumn {
+	return []converterColumn{
+		{&conv.Dec, 10},
+		{&conv.Hex, 16},
+		{&conv.Oct, 8},
+		{&conv.Bin, 2},
+		{&conv.Other, conv.otherRadix()},
+	}
+}
+
+// restore recomputes the value after the columns have been restored. Saved
+// columns agree with each other, or only the column that failed to parse is
+// set, so the first one that is not empty is converted. The columns are then
+// marked unchanged, so that Layout does not convert them again as if they
+// had been edited, with the value from before they were restored.
+func (conv *DecHexBinConverter) restore() {
+	conv.value = nil
+	conv.source = nil
+	conv.status = ""
+
+	columns := conv.columns()
+
+	for _, column := range columns {
+		if strings.TrimSpace(column.field.Text()) != "" {
+			conv.source = column.field
+			conv.convert(columns)
+
+			break
+		}
+	}
+
+	conv.Radix.Invalid = conv.otherRadix() == 0
+
+	for _, column := range columns {
+		column.field.Changed()
+	}
+
+	conv.Radix.Changed()
+}
+
+// convert parses the last edited column and fills in the others. With a
+// fixed width the decimal column holds the signed or unsigned value and the
+// other columns hold its two's complement bit pattern.
+func (conv *DecHexBinConverter) convert(columns []converterColumn) {
+	conv.value = nil
+	conv.status = ""
+
+	var source converterColumn
+
+	for _, column := range columns {
+		if column.field == conv.source {
+			source = column
+		}
+	}
+
+	value, err := calc.ParseBigNumber(source.field.Text(), source.radix)
+
+	if width, signed := conv.width(); err == nil && width != 0 {
+		if source.field == &conv.Dec {
+			_, err = calc.EncodeTwosComplement(value, width, signed)
+		} else {
+			value, err = calc.DecodeTwosComplement(value, width, signed)
+		}
+	}
+
+	source.field.Invalid = err != nil
+
+	if err == nil {
+		conv.value = value
+	} else if errors.Is(err, calc.ErrOutOfRange) {
+		conv.status = err.Error()
+	}
+
+	for _, column := range columns {
+		if column.field != source.field {
+			column.field.SetText(conv.format(column.radix))
+		}
+	}
+}
+
+// format formats the current value for a column, or returns "" if there is
+// no valid value.
+func (conv *DecHexBinConverter) format(radix int) string {
+	if conv.value == nil || radix == 0 {
+		return ""
+	}
+
+	width, signed := conv.width()
+	if width == 0 || radix == 10 {
+		return calc.FormatBigNumber(conv.value, radix)
+	}
+
+	pattern, err := calc.EncodeTwosComplement(conv.value, width, signed)
+	if err != nil {
+		return ""
+	}
+
+	switch radix {
+	case 2:
+		return calc.FormatBitwiseBin(pattern, width)
+	case 16:
+		return calc.FormatBitwiseHex(pattern, width)
+	default:
+		return calc.FormatBigNumber(pattern, radix)
+	}
+}
+
+// width returns the selected bit width, or 0 for arbitrary precision, and
+// whether values are signed.
+func (conv *DecHexBinConverter) width() (int, bool) {
+	width, _ := strconv.Atoi(conv.Width.Value)
+
+	return width, conv.Signed.Value
+}
+
+// otherRadix returns the radix entered for the custom column, or 0 if it is
+// not between calc.MinRadix and calc.MaxRadix.
+func (conv *DecHexBinConverter) otherRadix() int {
+	radix, err := strconv.Atoi(strings.TrimSpace(conv.Radix.Text()))
+	if err != nil || radix < calc.MinRadix || radix > calc.MaxRadix {
+		return 0
+	}
+
+	return radix
+}
+
+type BitwiseWorkbench struct {
+	Operand1 Field       `state:"Operand1"`
+	Operand2 Field       `state:"Operand2"`
+	Operator widget.Enum `state:"Operator"`
+	Width    widget.Enum `state:"Width"`
+	Result   StepList
+}
+
+func (bench *BitwiseWorkbench) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	changed := bench.Operand1.Changed()
+	changed = bench.Operand2.Changed() || changed
+	changed = bench.Operator.Changed() || changed
+	changed = bench.Width.Changed() || changed
+
+	if changed {
+		bench.Result.Steps = nil
+
+		op, _ := calc.ParseBitwiseOperator(bench.Operator.Value)
+		width, _ := strconv.Atoi(bench.Width.Value)
+
+		a, errA := calc.ParseBitwiseOperand(bench.Operand1.Text(), width)
+		bench.Operand1.Invalid = errA != nil
+
+		b := new(big.Int)
+		errB := error(nil)
+
+		if op.Shift() {
+			b, errB = calc.ParseBitwiseOperand(bench.Operand2.Text(), 32)
+		} else if !op.Unary() {
+			b, errB = calc.ParseBitwiseOperand(bench.Operand2.Text(), width)
+		}
+
+		bench.Operand2.Invalid = errB != nil
+
+		if bench.Operand1.Text() != "" && (op.Unary() || bench.Operand2.Text() != "") {
+			if errA == nil && errB == nil {
+				result, err := calc.ApplyBitwise(op, a, b, width)
+				bench.Operand2.Invalid = err != nil
+
+				if err != nil {
+					bench.Result.Steps = []string{err.Error()}
+				} else {
+					bench.Result.Steps = formatBitwiseTable(op, a, b, result, width)
+				}
+			}
+		}
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	operators := make([]layout.FlexChild, 0, len(calc.BitwiseOperators))
+	for _, op := range calc.BitwiseOperators {
+		operators = append(operators, layout.Rigid(material.RadioButton(th, &bench.Operator, op.String(), op.String()).Layout))
+	}
+
+	widths := make([]layout.FlexChild, 0, len(calc.BitwiseWidths)+1)
+	widths = append(widths, layout.Rigid(material.Body1(th, "Width:").Layout))
+
+	for _, width := range calc.BitwiseWidths {
+		widths = append(widths, layout.Rigid(material.RadioButton(th, &bench.Width, strconv.Itoa(width), strconv.Itoa(width)).Layout))
+	}
+
+	secondLabel := "Second:"
+	if op, _ := calc.ParseBitwiseOperator(bench.Operator.Value); op.Shift() {
+		secondLabel = "Bits:"
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "First:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return bench.Operand1.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, secondLabel).Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return bench.Operand2.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "(0b binary, 0x hex, or decimal)").Layout),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx, operators...)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx, widths...)
 		}),
-		spacer,
-		layout.Rigid(material.Body1(th, "Second:").Layout),
-		spacer,
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return conv.Bin2.Layout(th, gtx)
+			return bench.Result.Layout(th, gtx)
 		}),
-		spacer,
-		layout.Rigid(material.Body1(th, "Result:").Layout),
-		spacer,
+	)
+}
+
+const (
+	subnetterBySubnetCount = "subnets"
+	subnetterByHostCount   = "hosts"
+)
+
+type Subnetter struct {
+	Network Field       `state:"Network"`
+	Mode    widget.Enum `state:"Mode"`
+	Count   Field       `state:"Count"`
+	Result  StepList
+}
+
+func (subnetter *Subnetter) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	networkChanged := subnetter.Network.Changed()
+	countChanged := subnetter.Count.Changed()
+	modeChanged := subnetter.Mode.Changed()
+
+	if networkChanged || countChanged || modeChanged {
+		subnetter.Result.Steps = nil
+
+		if subnetter.Network.Text() != "" && subnetter.Count.Text() != "" {
+			var subnets []calc.Subnet
+			var steps []string
+
+			network, err := netip.ParsePrefix(strings.TrimSpace(subnetter.Network.Text()))
+			subnetter.Network.Invalid = err != nil
+
+			count, err := strconv.Atoi(strings.TrimSpace(subnetter.Count.Text()))
+			subnetter.Count.Invalid = err != nil
+
+			if !subnetter.Network.Invalid && !subnetter.Count.Invalid {
+				if subnetter.Mode.Value == subnetterByHostCount {
+					subnets, steps, err = calc.SplitNetworkByHostCount(network, count)
+				} else {
+					subnets, steps, err = calc.SplitNetworkBySubnetCount(network, count)
+				}
+
+				subnetter.Network.Invalid = err != nil
+				subnetter.Count.Invalid = err != nil
+
+				if err != nil {
+					subnetter.Result.Steps = []string{err.Error()}
+				} else {
+					subnetter.Result.Steps = formatSubnettingSteps(subnets, steps)
+				}
+			}
+		}
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Network:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return subnetter.Network.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.RadioButton(th, &subnetter.Mode, subnetterBySubnetCount, "Subnets").Layout),
+				layout.Rigid(material.RadioButton(th, &subnetter.Mode, subnetterByHostCount, "Hosts per subnet").Layout),
+				spacer,
+				layout.Rigid(material.Body1(th, "Count:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return subnetter.Count.Layout(th, gtx)
+				}),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: padding2}.Layout),
 		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
-			return material.Clickable(gtx, &conv.Result, material.Body1(th, conv.ResultValue).Layout)
+			return subnetter.Result.Layout(th, gtx)
 		}),
 	)
 }
+
+type VLSMPlanner struct {
+	Network      Field `state:"Network"`
+	Requirements Field `state:"Requirements"`
+	Result       StepList
+}
+
+func (planner *VLSMPlanner) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	networkChanged := planner.Network.Changed()
+	requirementsChanged := planner.Requirements.Changed()
+
+	if networkChanged || requirementsChanged {
+		planner.Result.Steps = nil
+
+		if planner.Network.Text() != "" && planner.Requirements.Text() != "" {
+			network, err := netip.ParsePrefix(strings.TrimSpace(planner.Network.Text()))
+			planner.Network.Invalid = err != nil
+
+			requirements, err := calc.ParseVLSMRequirements(planner.Requirements.Text())
+			planner.Requirements.Invalid = err != nil
+
+			if err != nil {
+				planner.Result.Steps = []string{err.Error()}
+			} else if !planner.Network.Invalid {
+				plan, steps, err := calc.PlanVLSM(network, requirements)
+				planner.Network.Invalid = err != nil
+				planner.Result.Steps = formatVLSMSteps(plan, steps, err)
+			}
+		}
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Network:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return planner.Network.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "Requirements (e.g. Sales 120, WAN 2):").Layout),
+				spacer,
+				layout.Flexed(2, func(gtx layout.Context) layout.Dimensions {
+					return planner.Requirements.Layout(th, gtx)
+				}),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: padding2}.Layout),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return planner.Result.Layout(th, gtx)
+		}),
+	)
+}
+
+const (
+	checksumCompute = "compute"
+	checksumVerify  = "verify"
+)
+
+type ChecksumCalculator struct {
+	Bytes       Field       `state:"Bytes"`
+	Mode        widget.Enum `state:"Mode"`
+	Result      widget.Clickable
+	ResultValue string
+	Steps       StepList
+}
+
+func (calculator *ChecksumCalculator) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	bytesChanged := calculator.Bytes.Changed()
+	modeChanged := calculator.Mode.Changed()
+
+	if bytesChanged || modeChanged {
+		calculator.ResultValue = ""
+		calculator.Steps.Steps = nil
+
+		if calculator.Bytes.Text() != "" {
+			data, err := calc.ParseHexBytes(calculator.Bytes.Text())
+			calculator.Bytes.Invalid = err != nil
+
+			if err == nil && calculator.Mode.Value == checksumVerify {
+				valid, steps := calc.VerifyInternetChecksum(data)
+				calculator.ResultValue = strconv.FormatBool(valid)
+				calculator.Steps.Steps = steps
+			} else if err == nil {
+				checksum, steps := calc.InternetChecksum(data)
+				calculator.ResultValue = fmt.Sprintf("%04X", checksum)
+				calculator.Steps.Steps = steps
+			}
+		}
+	}
+
+	if calculator.Result.Clicked() {
+		clipboard.WriteOp{Text: calculator.ResultValue}.Add(gtx.Ops)
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+	resultLabel := "Checksum:"
+
+	if calculator.Mode.Value == checksumVerify {
+		resultLabel = "Valid?"
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Hex bytes:").Layout),
+				spacer,
+				layout.Flexed(3, func(gtx layout.Context) layout.Dimensions {
+					return calculator.Bytes.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.RadioButton(th, &calculator.Mode, checksumCompute, "Compute").Layout),
+				layout.Rigid(material.RadioButton(th, &calculator.Mode, checksumVerify, "Verify").Layout),
+				spacer,
+				layout.Rigid(material.Body1(th, resultLabel).Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return material.Clickable(gtx, &calculator.Result, material.Body1(th, calculator.ResultValue).Layout)
+				}),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: padding2}.Layout),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return calculator.Steps.Layout(th, gtx)
+		}),
+	)
+}
+
+const crcCustom = "Custom"
+
+type CRCCalculator struct {
+	Bytes       Field       `state:"Bytes"`
+	Preset      widget.Enum `state:"Preset"`
+	Polynomial  Field       `state:"Polynomial"`
+	Init        Field       `state:"Init"`
+	XorOut      Field       `state:"XorOut"`
+	ReflectIn   widget.Bool `state:"ReflectIn"`
+	ReflectOut  widget.Bool `state:"ReflectOut"`
+	Result      widget.Clickable
+	ResultValue string
+	Steps       StepList
+}
+
+func (calculator *CRCCalculator) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	changed := calculator.Bytes.Changed()
+	changed = calculator.Preset.Changed() || changed
+	changed = calculator.Polynomial.Changed() || changed
+	changed = calculator.Init.Changed() || changed
+	changed = calculator.XorOut.Changed() || changed
+	changed = calculator.ReflectIn.Changed() || changed
+	changed = calculator.ReflectOut.Changed() || changed
+
+	if changed {
+		calculator.ResultValue = ""
+		calculator.Steps.Steps = nil
+
+		params, paramsErr := calculator.parameters()
+
+		if calculator.Bytes.Text() != "" && paramsErr == nil {
+			data, err := calc.ParseHexBytes(calculator.Bytes.Text())
+			calculator.Bytes.Invalid = err != nil
+
+			if err == nil {
+				crc, _ := calc.ComputeCRC(params, data)
+				calculator.ResultValue = calc.FormatCRC(params, crc)
+
+				steps, err := calc.CRCDivisionSteps(params, data)
+				if err != nil {
+					calculator.Steps.Steps = []string{err.Error()}
+				} else {
+					calculator.Steps.Steps = append(steps, fmt.Sprintf("%s = %s", params.Name, calculator.ResultValue))
+				}
+			}
+		}
+	}
+
+	if calculator.Result.Clicked() {
+		clipboard.WriteOp{Text: calculator.ResultValue}.Add(gtx.Ops)
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	presets := make([]layout.FlexChild, 0, len(calc.CRCPresets)+1)
+	for _, preset := range calc.CRCPresets {
+		presets = append(presets, layout.Rigid(material.RadioButton(th, &calculator.Preset, preset.Name, preset.Name).Layout))
+	}
+
+	presets = append(presets, layout.Rigid(material.RadioButton(th, &calculator.Preset, crcCustom, crcCustom).Layout))
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Hex bytes:").Layout),
+				spacer,
+				layout.Flexed(3, func(gtx layout.Context) layout.Dimensions {
+					return calculator.Bytes.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "CRC:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return material.Clickable(gtx, &calculator.Result, material.Body1(th, calculator.ResultValue).Layout)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx, presets...)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if calculator.Preset.Value != crcCustom {
+				return layout.Dimensions{}
+			}
+
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Polynomial:").Layout),
+				spacer,
+				layout.Flexed(2, func(gtx layout.Context) layout.Dimensions {
+					return calculator.Polynomial.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "Init:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return calculator.Init.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "XorOut:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return calculator.XorOut.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.CheckBox(th, &calculator.ReflectIn, "Reflect in").Layout),
+				layout.Rigid(material.CheckBox(th, &calculator.ReflectOut, "Reflect out").Layout),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: padding2}.Layout),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return calculator.Steps.Layout(th, gtx)
+		}),
+	)
+}
+
+func (calculator *CRCCalculator) parameters() (calc.CRCParameters, error) {
+	if calculator.Preset.Value != crcCustom {
+		return calc.FindCRCPreset(calculator.Preset.Value)
+	}
+
+	width, polynomial, err := calc.ParseCRCPolynomial(calculator.Polynomial.Text())
+	calculator.Polynomial.Invalid = err != nil
+
+	if err != nil {
+		return calc.CRCParameters{}, err
+	}
+
+	params := calc.CRCParameters{
+		Name:       crcCustom,
+		Width:      width,
+		Polynomial: polynomial,
+		ReflectIn:  calculator.ReflectIn.Value,
+		ReflectOut: calculator.ReflectOut.Value,
+	}
+
+	if params.Init, err = parseOptionalHex(calculator.Init.Text()); err != nil {
+		calculator.Init.Invalid = true
+		return calc.CRCParameters{}, err
+	}
+
+	calculator.Init.Invalid = false
+
+	if params.XorOut, err = parseOptionalHex(calculator.XorOut.Text()); err != nil {
+		calculator.XorOut.Invalid = true
+		return calc.CRCParameters{}, err
+	}
+
+	calculator.XorOut.Invalid = false
+
+	return params, nil
+}
+
+func parseOptionalHex(hexNumber string) (uint64, error) {
+	trimmedHexNumber := strings.TrimPrefix(strings.ToLower(strings.ReplaceAll(hexNumber, " ", "")), "0x")
+	if trimmedHexNumber == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseUint(trimmedHexNumber, 16, 64)
+}
+
+type BogonFilterGenerator struct {
+	Categories []widget.Bool `state:"Categories"`
+	Format     widget.Enum   `state:"Format"`
+	Name       Field         `state:"Name"`
+	Copy       widget.Clickable
+	Result     StepList
+
+	filter string
+}
+
+func (generator *BogonFilterGenerator) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	changed := false
+
+	for i := range generator.Categories {
+		changed = generator.Categories[i].Changed() || changed
+	}
+
+	changed = generator.Format.Changed() || changed
+	changed = generator.Name.Changed() || changed
+
+	if changed {
+		generator.generate()
+	}
+
+	if generator.Copy.Clicked() {
+		clipboard.WriteOp{Text: generator.filter}.Add(gtx.Ops)
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	categories := make([]layout.FlexChild, 0, len(calc.BogonCategories))
+	for i, category := range calc.BogonCategories {
+		categories = append(categories, layout.Rigid(material.CheckBox(th, &generator.Categories[i], category.Name).Layout))
+	}
+
+	formats := []layout.FlexChild{layout.Rigid(material.Body1(th, "Format:").Layout)}
+	for _, format := range calc.BogonFilterFormats {
+		formats = append(formats, layout.Rigid(material.RadioButton(th, &generator.Format, format, format).Layout))
+	}
+
+	formats = append(formats,
+		spacer,
+		layout.Rigid(material.Body1(th, "Name:").Layout),
+		spacer,
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return generator.Name.Layout(th, gtx)
+		}),
+		spacer,
+		layout.Rigid(material.Button(th, &generator.Copy, "Copy").Layout),
+	)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx, categories...)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx, formats...)
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return generator.Result.Layout(th, gtx)
+		}),
+	)
+}
+
+// setDefaultCategories selects the categories that are in a bogon filter by
+// default.
+func (generator *BogonFilterGenerator) setDefaultCategories() {
+	generator.Categories = make([]widget.Bool, len(calc.BogonCategories))
+
+	for i, category := range calc.BogonCategories {
+		generator.Categories[i].Value = category.Default
+	}
+}
+
+func (generator *BogonFilterGenerator) generate() {
+	var categories []string
+
+	for i, category := range calc.BogonCategories {
+		if generator.Categories[i].Value {
+			categories = append(categories, category.Name)
+		}
+	}
+
+	name := strings.TrimSpace(generator.Name.Text())
+	generator.Name.Invalid = name == "" || strings.ContainsAny(name, " \t\"{};")
+
+	generator.filter = ""
+	generator.Result.Steps = nil
+
+	if generator.Name.Invalid {
+		return
+	}
+
+	prefixes, err := calc.BogonPrefixes(categories)
+	if err == nil {
+		generator.filter, err = calc.FormatBogonFilter(prefixes, generator.Format.Value, name)
+	}
+
+	if err != nil {
+		generator.Result.Steps = []string{err.Error()}
+		return
+	}
+
+	generator.Result.Steps = strings.Split(strings.TrimSuffix(generator.filter, "\n"), "\n")
+}
+
+type RangeCIDRConverter struct {
+	Range       Field `state:"Range"`
+	CIDR        Field `state:"CIDR"`
+	Result      StepList
+	Copy        widget.Clickable
+	ResultValue string
+}
+
+func (conv *RangeCIDRConverter) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	if conv.Range.Changed() {
+		conv.Result.Steps = nil
+		conv.ResultValue = ""
+
+		start, end, err := calc.ParseAddrRange(conv.Range.Text())
+
+		var prefixes []netip.Prefix
+		var steps []string
+
+		if err == nil {
+			prefixes, steps, err = calc.RangeToCIDRs(start, end)
+		}
+
+		conv.Range.Invalid = err != nil
+
+		if err == nil {
+			conv.ResultValue = joinPrefixes(prefixes, "\n")
+			conv.Result.Steps = append(formatNumberedSteps(steps), "Prefixes: "+joinPrefixes(prefixes, ", "))
+		}
+	}
+
+	if conv.CIDR.Changed() {
+		conv.Result.Steps = nil
+		conv.ResultValue = ""
+
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(conv.CIDR.Text()))
+		if err != nil {
+			prefix, err = calc.ParseHostPrefix(conv.CIDR.Text())
+		}
+
+		var first, last netip.Addr
+		var steps []string
+
+		if err == nil {
+			first, last, steps, err = calc.PrefixRange(prefix)
+		}
+
+		conv.CIDR.Invalid = err != nil
+
+		if err == nil {
+			conv.ResultValue = first.String() + "-" + last.String()
+			conv.Result.Steps = append(formatNumberedSteps(steps), "Range: "+conv.ResultValue)
+		}
+	}
+
+	if conv.Copy.Clicked() {
+		clipboard.WriteOp{Text: conv.ResultValue}.Add(gtx.Ops)
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Range (start-end):").Layout),
+				spacer,
+				layout.Flexed(2, func(gtx layout.Context) layout.Dimensions {
+					return conv.Range.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "CIDR:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return conv.CIDR.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Button(th, &conv.Copy, "Copy result").Layout),
+			)
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return conv.Result.Layout(th, gtx)
+		}),
+	)
+}
+
+type PrefixAggregator struct {
+	Prefixes    Field       `state:"Prefixes"`
+	Supernet    widget.Bool `state:"Supernet"`
+	Result      StepList
+	Copy        widget.Clickable
+	ResultValue string
+}
+
+func (aggregator *PrefixAggregator) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	prefixesChanged := aggregator.Prefixes.Changed()
+	supernetChanged := aggregator.Supernet.Changed()
+
+	if prefixesChanged || supernetChanged {
+		aggregator.update()
+	}
+
+	if aggregator.Copy.Clicked() {
+		clipboard.WriteOp{Text: aggregator.ResultValue}.Add(gtx.Ops)
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Prefixes:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return aggregator.Prefixes.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.CheckBox(th, &aggregator.Supernet, "Single supernet").Layout),
+				spacer,
+				layout.Rigid(material.Button(th, &aggregator.Copy, "Copy result").Layout),
+			)
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return aggregator.Result.Layout(th, gtx)
+		}),
+	)
+}
+
+func (aggregator *PrefixAggregator) update() {
+	aggregator.Result.Steps = nil
+	aggregator.ResultValue = ""
+
+	if strings.TrimSpace(aggregator.Prefixes.Text()) == "" {
+		aggregator.Prefixes.Invalid = false
+		return
+	}
+
+	prefixes, err := parsePrefixList(aggregator.Prefixes.Text())
+
+	var aggregated []netip.Prefix
+	var steps []string
+
+	if err == nil {
+		aggregated, steps, err = calc.AggregatePrefixes(prefixes)
+	}
+
+	aggregator.Prefixes.Invalid = err != nil
+
+	if err != nil {
+		return
+	}
+
+	if !aggregator.Supernet.Value {
+		aggregator.ResultValue = joinPrefixes(aggregated, "\n")
+		aggregator.Result.Steps = append(formatNumberedSteps(steps), "Aggregated: "+joinPrefixes(aggregated, ", "))
+		return
+	}
+
+	supernet, extra, supernetSteps, err := calc.Supernet(prefixes)
+	if err != nil {
+		aggregator.Result.Steps = []string{err.Error()}
+		return
+	}
+
+	aggregator.ResultValue = supernet.String()
+	aggregator.Result.Steps = formatSupernetSteps(steps, supernetSteps, supernet, extra)
+}
+
+type WildcardACLGenerator struct {
+	NetMask  Field       `state:"NetMask"`
+	Wildcard Field       `state:"Wildcard"`
+	Targets  Field       `state:"Targets"`
+	Name     Field       `state:"Name"`
+	Action   widget.Enum `state:"Action"`
+	Copy     widget.Clickable
+	Result   StepList
+
+	acl string
+}
+
+func (generator *WildcardACLGenerator) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	if generator.NetMask.Changed() {
+		generator.Result.Steps = nil
+
+		netMask, err := netip.ParseAddr(strings.TrimSpace(generator.NetMask.Text()))
+
+		var wildcard netip.Addr
+		if err == nil {
+			wildcard, err = calc.NetworkMaskToWildcard(netMask)
+		}
+
+		generator.NetMask.Invalid = err != nil
+		generator.Wildcard.SetText(formatAddr(wildcard, err))
+
+		if err == nil {
+			generator.Result.Steps = formatWildcardSteps(netMask, wildcard)
+		} else if netMask.IsValid() {
+			generator.Result.Steps = formatMaskProblem(netMask)
+		}
+	}
+
+	if generator.Wildcard.Changed() {
+		generator.Result.Steps = nil
+
+		wildcard, err := netip.ParseAddr(strings.TrimSpace(generator.Wildcard.Text()))
+
+		var netMask netip.Addr
+		if err == nil {
+			netMask, err = calc.WildcardToNetworkMask(wildcard)
+		}
+
+		generator.Wildcard.Invalid = err != nil
+		generator.NetMask.SetText(formatAddr(netMask, err))
+
+		if err == nil {
+			generator.Result.Steps = formatWildcardSteps(netMask, wildcard)
+		} else if wildcard.Is4() {
+			generator.Result.Steps = []string{err.Error()}
+		}
+	}
+
+	changed := generator.Targets.Changed()
+	changed = generator.Name.Changed() || changed
+	changed = generator.Action.Changed() || changed
+
+	if changed {
+		generator.generate()
+	}
+
+	if generator.Copy.Clicked() {
+		clipboard.WriteOp{Text: generator.acl}.Add(gtx.Ops)
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Network mask:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return generator.NetMask.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "Wildcard mask:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return generator.Wildcard.Layout(th, gtx)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "Prefixes, hosts or ranges:").Layout),
+				spacer,
+				layout.Flexed(3, func(gtx layout.Context) layout.Dimensions {
+					return generator.Targets.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "ACL:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return generator.Name.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.RadioButton(th, &generator.Action, "permit", "permit").Layout),
+				layout.Rigid(material.RadioButton(th, &generator.Action, "deny", "deny").Layout),
+				spacer,
+				layout.Rigid(material.Button(th, &generator.Copy, "Copy ACL").Layout),
+			)
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return generator.Result.Layout(th, gtx)
+		}),
+	)
+}
+
+func (generator *WildcardACLGenerator) generate() {
+	generator.Result.Steps = nil
+	generator.acl = ""
+
+	if strings.TrimSpace(generator.Targets.Text()) == "" {
+		generator.Targets.Invalid = false
+		return
+	}
+
+	prefixes, err := parseACLTargets(generator.Targets.Text())
+
+	var entries []calc.WildcardEntry
+	var steps []string
+
+	if err == nil {
+		entries, steps, err = calc.WildcardEntries(prefixes)
+	}
+
+	generator.Targets.Invalid = err != nil
+
+	if err != nil {
+		return
+	}
+
+	generator.acl, err = calc.FormatACL(entries, strings.TrimSpace(generator.Name.Text()), generator.Action.Value)
+	generator.Name.Invalid = err != nil
+
+	if err != nil {
+		generator.Result.Steps = []string{err.Error()}
+		return
+	}
+
+	generator.Result.Steps = append(formatNumberedSteps(steps), strings.Split(strings.TrimSuffix(generator.acl, "\n"), "\n")...)
+}
+
+type SubnetVisualizer struct {
+	Network      Field `state:"Network"`
+	SubnetPrefix Field `state:"SubnetPrefix"`
+	Bits         BitVisualizer
+	Details      StepList
+}
+
+func (visualizer *SubnetVisualizer) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	networkChanged := visualizer.Network.Changed()
+	subnetPrefixChanged := visualizer.SubnetPrefix.Changed()
+
+	if networkChanged || subnetPrefixChanged {
+		visualizer.update()
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(material.Body1(th, "IPv4 address/network prefix:").Layout),
+				spacer,
+				layout.Flexed(2, func(gtx layout.Context) layout.Dimensions {
+					return visualizer.Network.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, "Subnet prefix:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return visualizer.SubnetPrefix.Layout(th, gtx)
+				}),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: padding2}.Layout),
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			dims := visualizer.Bits.Layout(th, gtx)
+
+			// The prefix is dragged while the bits are laid out, so the
+			// details below follow the marker in the same frame.
+			if visualizer.Bits.Changed() {
+				visualizer.SubnetPrefix.SetText(calc.FormatCIDRSlashValue(visualizer.Bits.Prefix))
+				visualizer.update()
+			}
+
+			return dims
+		}),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return visualizer.Details.Layout(th, gtx)
+		}),
+	)
+}
+
+func (visualizer *SubnetVisualizer) update() {
+	visualizer.Details.Steps = nil
+
+	network, err := calc.ParseHostPrefix(visualizer.Network.Text())
+	if err == nil && !network.Addr().Is4() {
+		err = calc.ErrNotIPv4
+	}
+
+	visualizer.Network.Invalid = err != nil
+
+	if err != nil {
+		visualizer.Bits.Address = netip.Addr{}
+		return
+	}
+
+	subnetPrefix := network.Bits()
+
+	if strings.TrimSpace(visualizer.SubnetPrefix.Text()) != "" {
+		subnetPrefix, err = calc.ParsePrefixLength(visualizer.SubnetPrefix.Text(), 32)
+		visualizer.SubnetPrefix.Invalid = err != nil
+
+		if err != nil {
+			return
+		}
+	}
+
+	visualizer.Bits.Address = network.Addr()
+	visualizer.Bits.NetworkPrefix = network.Bits()
+	visualizer.Bits.Prefix = subnetPrefix
+
+	visualizer.Details.Steps, err = formatBitVisualization(network.Addr(), network.Bits(), subnetPrefix)
+	if err != nil {
+		visualizer.Details.Steps = []string{err.Error()}
+	}
+}
+
+// PasteInspector recognizes whatever is pasted into it and shows every
+// result that applies, so that the user does not have to find the right
+// calculator first.
+type PasteInspector struct {
+	Input  Field
+	Clear  widget.Clickable
+	Result StepList
+}
+
+func (inspector *PasteInspector) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	if inspector.Clear.Clicked() {
+		inspector.Input.SetText("")
+		inspector.Result.Steps = nil
+	}
+
+	if inspector.Input.Changed() {
+		interpretations := interpretPaste(inspector.Input.Text())
+		inspector.Input.Invalid = len(interpretations) == 0
+		inspector.Result.Steps = nil
+
+		if strings.TrimSpace(inspector.Input.Text()) != "" {
+			inspector.Result.Steps = formatInterpretations(interpretations)
+		}
+	}
+
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(Heading(th, "Paste anything:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return inspector.Input.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Button(th, &inspector.Clear, "Clear").Layout),
+			)
+		}),
+	}
+
+	if len(inspector.Result.Steps) > 0 {
+		children = append(children, layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Max.Y = gtx.Dp(pasteResultHeight)
+			return inspector.Result.Layout(th, gtx)
+		}))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+type HistoryView struct {
+	Search  Field
+	List    widget.List
+	Restore []widget.Clickable
+
+	matches []historyEntry
+	count   int
+}
+
+func (view *HistoryView) Layout(th *material.Theme, gtx layout.Context, historyErr error) layout.Dimensions {
+	view.List.Axis = layout.Vertical
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	status := fmt.Sprintf("%d calculations", len(view.matches))
+	if historyErr != nil {
+		status = "History is not saved: " + historyErr.Error()
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(Heading(th, "Search history:").Layout),
+				spacer,
+				layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+					return view.Search.Layout(th, gtx)
+				}),
+				spacer,
+				layout.Rigid(material.Body1(th, status).Layout),
+			)
+		}),
+		layout.Rigid(layout.Spacer{Height: padding1}.Layout),
+		layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+			return material.List(th, &view.List).Layout(gtx, len(view.matches), func(gtx layout.Context, i int) layout.Dimensions {
+				entry := view.matches[i]
+
+				summary := material.Body1(th, entry.Summary())
+				summary.Font.Variant = "Mono"
+
+				return layout.Inset{Right: padding1, Bottom: padding1}.Layout(gtx, func(gtx layout.Context) layout.Dimensions {
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, func(gtx layout.Context) layout.Dimensions {
+							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+								layout.Rigid(Subheading(th, entry.Time.Local().Format("2006-01-02 15:04")+"  "+entry.Calculator).Layout),
+								layout.Rigid(summary.Layout),
+							)
+						}),
+						spacer,
+						layout.Rigid(material.Button(th, &view.Restore[i], "Restore").Layout),
+					)
+				})
+			})
+		}),
+	)
+}
+
+// Toolbar holds the undo buttons and saves and loads named sessions, each
+// the state of every calculator.
+type Toolbar struct {
+	Undo        widget.Clickable
+	Redo        widget.Clickable
+	SessionName Field
+	Save        widget.Clickable
+	Load        widget.Clickable
+
+	Status string
+}
+
+func (toolbar *Toolbar) Layout(th *material.Theme, gtx layout.Context, canUndo bool, canRedo bool) layout.Dimensions {
+	spacer := layout.Rigid(layout.Spacer{Width: padding2}.Layout)
+
+	button := func(clickable *widget.Clickable, label string, enabled bool) layout.FlexChild {
+		return layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			if !enabled {
+				gtx = gtx.Disabled()
+			}
+
+			return material.Button(th, clickable, label).Layout(gtx)
+		})
+	}
+
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		button(&toolbar.Undo, "Undo", canUndo),
+		spacer,
+		button(&toolbar.Redo, "Redo", canRedo),
+		layout.Rigid(layout.Spacer{Width: padding1}.Layout),
+		layout.Rigid(Heading(th, "Session:").Layout),
+		spacer,
+		layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+			gtx.Constraints.Min.X = gtx.Dp(sessionNameWidth)
+			gtx.Constraints.Max.X = gtx.Constraints.Min.X
+
+			return toolbar.SessionName.Layout(th, gtx)
+		}),
+		spacer,
+		button(&toolbar.Save, "Save", true),
+		spacer,
+		button(&toolbar.Load, "Load", true),
+		spacer,
+		layout.Flexed(1, material.Body1(th, toolbar.Status).Layout),
+	)
+}
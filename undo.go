@@ -0,0 +1,97 @@
+package main
+
+import "time"
+
+// undoCoalesceDelay is how soon after the previous change to the same
+// calculator a change joins its transaction, so that typing a value is
+// undone as a whole rather than one character at a time.
+const undoCoalesceDelay = time.Second
+
+// maxUndoTransactions bounds the memory the undo stack can use.
+const maxUndoTransactions = 200
+
+// stateChange is the state of one calculator before and after a change.
+type stateChange struct {
+	Calculator Calculator
+	Before     map[string]string
+	After      map[string]string
+}
+
+// transaction is what one undo or redo reverts or reapplies: a user edit
+// together with the fields it updated, or the calculators a restored
+// history entry or session changed.
+type transaction struct {
+	Changes []stateChange
+	At      time.Time
+}
+
+type undoStack struct {
+	undo []transaction
+	redo []transaction
+}
+
+// RecordEdit adds a change made by the user, joining it to the latest
+// transaction if that changed only the same calculator less than
+// undoCoalesceDelay ago.
+func (s *undoStack) RecordEdit(change stateChange, now time.Time) {
+	s.redo = nil
+
+	if len(s.undo) > 0 {
+		latest := &s.undo[len(s.undo)-1]
+
+		if len(latest.Changes) == 1 && latest.Changes[0].Calculator == change.Calculator && now.Sub(latest.At) < undoCoalesceDelay {
+			latest.Changes[0].After = change.After
+			latest.At = now
+
+			return
+		}
+	}
+
+	s.Record(transaction{Changes: []stateChange{change}, At: now})
+}
+
+// Record adds a transaction that is never joined with later edits.
+func (s *undoStack) Record(t transaction) {
+	s.redo = nil
+	s.undo = append(s.undo, t)
+
+	if len(s.undo) > maxUndoTransactions {
+		s.undo = s.undo[len(s.undo)-maxUndoTransactions:]
+	}
+}
+
+// Undo returns the latest transaction and moves it to the redo stack. The
+// caller restores the Before states of its changes.
+func (s *undoStack) Undo() (transaction, bool) {
+	if len(s.undo) == 0 {
+		return transaction{}, false
+	}
+
+	t := s.undo[len(s.undo)-1]
+	s.undo = s.undo[:len(s.undo)-1]
+	s.redo = append(s.redo, t)
+
+	return t, true
+}
+
+// Redo returns the latest undone transaction and moves it back to the undo
+// stack. The caller restores the After states of its changes.
+func (s *undoStack) Redo() (transaction, bool) {
+	if len(s.redo) == 0 {
+		return transaction{}, false
+	}
+
+	t := s.redo[len(s.redo)-1]
+	s.redo = s.redo[:len(s.redo)-1]
+	s.undo = append(s.undo, t)
+
+	return t, true
+}
+
+func (s *undoStack) CanUndo() bool {
+	return len(s.undo) > 0
+}
+
+func (s *undoStack) CanRedo() bool {
+	return len(s.redo) > 0
+}
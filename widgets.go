@@ -1,9 +1,16 @@
 package main
 
 import (
+	"image"
 	"image/color"
+	"net/netip"
 
+	"gioui.org/gesture"
+	"gioui.org/io/pointer"
 	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
 	"gioui.org/text"
 	"gioui.org/unit"
 	"gioui.org/widget"
@@ -31,6 +38,14 @@ func (ed *Field) SetText(s string) {
 	ed.Editor.SetText(s)
 }
 
+// Restore sets the text like SetText, but Changed reports it, so that
+// results depending on the field are recomputed.
+func (ed *Field) Restore(s string) {
+	ed.Invalid = false
+	ed.old = ""
+	ed.Editor.SetText(s)
+}
+
 func (ed *Field) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
 	borderWidth := float32(0.5)
 	if ed.Editor.Focused() {
@@ -57,6 +72,153 @@ func (ed *Field) Layout(th *material.Theme, gtx layout.Context) layout.Dimension
 	})
 }
 
+type StepList struct {
+	widget.List
+
+	Steps []string
+}
+
+func (sl *StepList) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	sl.List.Axis = layout.Vertical
+
+	return material.List(th, &sl.List).Layout(gtx, len(sl.Steps), func(gtx layout.Context, i int) layout.Dimensions {
+		label := material.Body1(th, sl.Steps[i])
+		label.Font.Variant = "Mono"
+
+		return label.Layout(gtx)
+	})
+}
+
+var (
+	networkBitColor   = color.NRGBA{R: 0x9c, G: 0xc4, B: 0xf0, A: 0xFF}
+	subnetBitColor    = color.NRGBA{R: 0xf4, G: 0xc0, B: 0x7c, A: 0xFF}
+	hostBitColor      = color.NRGBA{R: 0xb4, G: 0xe0, B: 0xa4, A: 0xFF}
+	prefixMarkerColor = color.NRGBA{R: 200, A: 0xFF}
+)
+
+// BitVisualizer draws the bits of an address grouped in octets, coloring the
+// bits of the network, the subnet bits borrowed from the host part of the
+// network and the host bits. Dragging the marker at the prefix boundary, or
+// clicking between two bits, changes Prefix.
+type BitVisualizer struct {
+	Address       netip.Addr
+	NetworkPrefix int
+	Prefix        int
+
+	drag    gesture.Drag
+	changed bool
+}
+
+// Changed reports whether Prefix has been dragged since the last call.
+func (v *BitVisualizer) Changed() bool {
+	changed := v.changed
+	v.changed = false
+
+	return changed
+}
+
+func (v *BitVisualizer) Layout(th *material.Theme, gtx layout.Context) layout.Dimensions {
+	if !v.Address.IsValid() {
+		return layout.Dimensions{}
+	}
+
+	octets := v.Address.AsSlice()
+	bits := len(octets) * 8
+
+	// Octets are separated by half a cell.
+	cellWidth := gtx.Constraints.Max.X * 2 / (bits*2 + len(octets) - 1)
+	cellHeight := gtx.Dp(unit.Dp(24))
+	gap := cellWidth / 2
+
+	cellX := func(i int) int {
+		return i*cellWidth + i/8*gap
+	}
+
+	boundaryX := func(prefix int) int {
+		switch {
+		case prefix == 0:
+			return 0
+		case prefix%8 == 0 && prefix < bits:
+			return cellX(prefix) - gap/2
+		default:
+			return cellX(prefix-1) + cellWidth
+		}
+	}
+
+	for _, e := range v.drag.Events(gtx.Metric, gtx, gesture.Horizontal) {
+		if e.Type != pointer.Press && e.Type != pointer.Drag {
+			continue
+		}
+
+		nearest := 0
+		for prefix := 1; prefix <= bits; prefix++ {
+			if absInt(boundaryX(prefix)-int(e.Position.X)) < absInt(boundaryX(nearest)-int(e.Position.X)) {
+				nearest = prefix
+			}
+		}
+
+		if nearest != v.Prefix {
+			v.Prefix = nearest
+			v.changed = true
+
+			// Widgets laid out before this one show the new prefix on the
+			// next frame.
+			op.InvalidateOp{}.Add(gtx.Ops)
+		}
+	}
+
+	size := image.Pt(cellX(bits-1)+cellWidth, cellHeight)
+
+	for i := 0; i < bits; i++ {
+		digit := "0"
+		if octets[i/8]&(0x80>>(i%8)) != 0 {
+			digit = "1"
+		}
+
+		offset := op.Offset(image.Pt(cellX(i), 0)).Push(gtx.Ops)
+		paint.FillShape(gtx.Ops, v.bitColor(i), clip.Rect{Max: image.Pt(cellWidth-1, cellHeight)}.Op())
+
+		cellGtx := gtx
+		cellGtx.Constraints = layout.Exact(image.Pt(cellWidth-1, cellHeight))
+
+		label := material.Body1(th, digit)
+		label.Font.Variant = "Mono"
+		layout.Center.Layout(cellGtx, label.Layout)
+
+		offset.Pop()
+	}
+
+	markerWidth := gtx.Dp(unit.Dp(3))
+	markerX := boundaryX(v.Prefix) - markerWidth/2
+	paint.FillShape(gtx.Ops, prefixMarkerColor, clip.Rect{Min: image.Pt(markerX, 0), Max: image.Pt(markerX+markerWidth, cellHeight)}.Op())
+
+	area := clip.Rect{Max: size}.Push(gtx.Ops)
+	pointer.CursorColResize.Add(gtx.Ops)
+	v.drag.Add(gtx.Ops)
+	area.Pop()
+
+	return layout.Dimensions{Size: size}
+}
+
+func (v *BitVisualizer) bitColor(i int) color.NRGBA {
+	switch {
+	case i < v.NetworkPrefix && i < v.Prefix:
+		return networkBitColor
+	case i < v.Prefix:
+		return subnetBitColor
+	default:
+		return hostBitColor
+	}
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
+
 func Heading(th *material.Theme, txt string) material.LabelStyle {
 	label := material.Label(th, th.TextSize*18.0/16.0, txt)
 	label.Font.Weight = text.Bold